@@ -4,10 +4,11 @@ import (
 	"log"
 
 	"github.com/cszczepaniak/sudoku-solver/cmd/cli/ui"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
 )
 
 func main() {
-	if err := ui.NewApp(nil).Run(); err != nil {
+	if err := ui.NewApp(solver.Board{}).Run(); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+const (
+	// animationDelay is how long the animation pauses after each step of the search.
+	animationDelay = 5 * time.Millisecond
+	// animationBudget is how many guesses an animated search may make before giving up.
+	animationBudget = 5000
+)
+
+// animator draws a search onto the board as it happens.
+type animator struct {
+	a *Application
+}
+
+var _ solver.Observer = animator{}
+
+func (an animator) OnPlace(r, c, n int) {
+	an.show(r, c, n)
+}
+
+func (an animator) OnBacktrack(r, c, n int) {
+	an.show(r, c, solver.Empty)
+}
+
+func (an animator) OnSolved(board solver.Board) {}
+
+func (an animator) show(r, c, n int) {
+	an.a.app.QueueUpdateDraw(func() {
+		an.a.updateCell(r, c, n)
+	})
+	time.Sleep(animationDelay)
+}
+
+// animate solves the board in the background, drawing every guess and backtrack as it goes.
+func (a *Application) animate() {
+	opts := append(a.solveOptions(),
+		solver.WithNodeBudget(animationBudget),
+		solver.WithObserver(animator{a: a}),
+	)
+	s, err := solver.New(a.board, opts...)
+	if err != nil {
+		return
+	}
+	a.animating = true
+	go func() {
+		// the search erases its guesses once it's done, so a solution is drawn again afterwards and a
+		// search that gives up leaves the board as it was
+		solved, err := s.Solve()
+		a.app.QueueUpdateDraw(func() {
+			a.animating = false
+			if err == nil {
+				a.board = solved
+				a.redrawBoard()
+			}
+		})
+	}()
+}
@@ -17,6 +17,10 @@ func (a *Application) initializeTable() {
 	}).SetSelectionChangedFunc(func(row, column int) {
 		a.currRow, a.currCol = row, column
 	}).SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if a.animating && event.Rune() != 'q' && event.Key() != tcell.KeyEscape {
+			// the board belongs to the animation until it finishes
+			return nil
+		}
 		switch event.Rune() {
 		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			newVal := int(event.Rune() - '0')
@@ -25,24 +29,22 @@ func (a *Application) initializeTable() {
 			a.board = solver.NewEmptyBoard()
 			a.redrawBoard()
 		case 'e':
-			a.board = [][]int{
-				{0, 0, 9, 0, 1, 6, 0, 4, 2},
-				{1, 0, 4, 2, 0, 9, 0, 6, 0},
-				{0, 2, 0, 0, 0, 8, 7, 0, 0},
-				{3, 5, 0, 0, 9, 0, 1, 0, 0},
-				{0, 6, 7, 4, 0, 1, 9, 0, 5},
-				{0, 0, 0, 7, 5, 0, 0, 8, 6},
-				{0, 9, 0, 0, 0, 4, 8, 5, 7},
-				{8, 0, 0, 9, 6, 0, 0, 2, 0},
-				{4, 7, 0, 8, 0, 5, 0, 0, 0},
+			p, err := a.gen.Generate()
+			if err == nil {
+				a.board = p.Board
+				a.redrawBoard()
 			}
+		case 'd':
+			a.diagonal = !a.diagonal
 			a.redrawBoard()
+		case 'a':
+			a.animate()
 		case 'q':
 			a.app.Stop()
 		}
 
 		if event.Key() == tcell.KeyEnter {
-			s, err := solver.New(a.board)
+			s, err := solver.New(a.board, a.solveOptions()...)
 			if err == nil {
 				solved, err := s.Solve()
 				if err == nil {
@@ -57,8 +59,18 @@ func (a *Application) initializeTable() {
 	a.redrawBoard()
 }
 
+// solveOptions returns the options for solving the board under the current rules. Searches fill in
+// forced moves first, which keeps them quick enough to run without freezing the interface.
+func (a *Application) solveOptions() []solver.Option {
+	opts := []solver.Option{solver.WithStrategy(solver.MostConstrained)}
+	if a.diagonal {
+		opts = append(opts, solver.WithDiagonals())
+	}
+	return opts
+}
+
 func (a *Application) updateCell(r, c, n int) {
-	a.board[r][c] = n
+	a.board.Set(r, c, n)
 	a.redrawCell(r, c, n)
 }
 
@@ -67,13 +79,17 @@ func (a *Application) redrawCell(r, c, n int) {
 	if n > 0 {
 		str = fmt.Sprintf(` %d `, n)
 	}
-	a.table.SetCell(r, c, tview.NewTableCell(str).SetAlign(tview.AlignCenter))
+	cell := tview.NewTableCell(str).SetAlign(tview.AlignCenter)
+	if a.diagonal && (r == c || r+c == a.board.Size()-1) {
+		cell.SetBackgroundColor(tcell.ColorDarkSlateGray)
+	}
+	a.table.SetCell(r, c, cell)
 }
 
 func (a *Application) redrawBoard() {
-	for i, r := range a.board {
-		for j, n := range r {
-			a.redrawCell(i, j, n)
+	for r := 0; r < a.board.Size(); r++ {
+		for c := 0; c < a.board.Size(); c++ {
+			a.redrawCell(r, c, a.board.Get(r, c))
 		}
 	}
 }
@@ -1,21 +1,27 @@
 package ui
 
 import (
+	"github.com/cszczepaniak/sudoku-solver/pkg/generator"
 	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
 	"github.com/rivo/tview"
 )
 
 type Application struct {
-	board   [][]int
-	currRow int
-	currCol int
+	board    solver.Board
+	currRow  int
+	currCol  int
+	diagonal bool
 
+	// animating is set while a search is being animated on the board.
+	animating bool
+
+	gen   *generator.Generator
 	table *tview.Table
 	app   *tview.Application
 }
 
-func NewApp(board [][]int) *Application {
-	if board == nil {
+func NewApp(board solver.Board) *Application {
+	if board.Size() == 0 {
 		board = solver.NewEmptyBoard()
 	}
 	a := &Application{
@@ -23,6 +29,7 @@ func NewApp(board [][]int) *Application {
 		board:   board,
 		currRow: 0,
 		currCol: 0,
+		gen:     generator.New(generator.WithSymmetry(generator.Rotational180)),
 	}
 	a.initializeTable()
 
@@ -37,10 +44,16 @@ func NewApp(board [][]int) *Application {
 		desc: `Clear Puzzle`,
 	}, {
 		name: `E`,
-		desc: `Load Example Puzzle`,
+		desc: `Generate Puzzle`,
+	}, {
+		name: `D`,
+		desc: `Toggle Diagonal Mode`,
 	}, {
 		name: `Enter`,
 		desc: `Solve Puzzle`,
+	}, {
+		name: `A`,
+		desc: `Animate Solving`,
 	}, {
 		name: `Esc, Q`,
 		desc: `Quit`,
@@ -0,0 +1,125 @@
+package format
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// Entry is a puzzle in a collection file.
+type Entry struct {
+	Board solver.Board
+	// Comment is the text after the puzzle on its line, such as a rating or a name, without the
+	// surrounding whitespace.
+	Comment string
+	// Line is the line of the file the puzzle was read from. Writing an entry ignores it.
+	Line int
+}
+
+// CollectionReader reads the puzzles of a collection file one at a time. Each puzzle is on a line
+// of its own, written as in ParseLine and optionally followed by a comment. Blank lines and lines
+// starting with '#' are skipped.
+type CollectionReader struct {
+	sc   *bufio.Scanner
+	line int
+	err  error
+}
+
+// NewCollectionReader returns a reader for the collection in r. It reads r as puzzles are asked for
+// rather than all at once.
+func NewCollectionReader(r io.Reader) *CollectionReader {
+	return &CollectionReader{
+		sc: bufio.NewScanner(r),
+	}
+}
+
+// Next returns the next puzzle of the collection. It returns false once there are no puzzles left
+// or a line can't be read, after which Err reports what went wrong.
+func (cr *CollectionReader) Next() (Entry, bool) {
+	if cr.err != nil {
+		return Entry{}, false
+	}
+	for cr.sc.Scan() {
+		cr.line++
+		line := strings.TrimRight(cr.sc.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == `` || strings.HasPrefix(trimmed, `#`) {
+			continue
+		}
+		entry, err := readEntry(line, cr.line)
+		if err != nil {
+			cr.err = err
+			return Entry{}, false
+		}
+		return entry, true
+	}
+	cr.err = cr.sc.Err()
+	return Entry{}, false
+}
+
+// Err returns the error that stopped the reader, or nil if it read the whole collection. A line
+// that isn't a puzzle is reported as a ParseError.
+func (cr *CollectionReader) Err() error {
+	return cr.err
+}
+
+// readEntry reads a puzzle and its comment from a line of a collection.
+func readEntry(line string, num int) (Entry, error) {
+	board, end, err := readSquares(line, num)
+	if err != nil {
+		return Entry{}, err
+	}
+	rest := line[end:]
+	if ch, size := utf8.DecodeRuneInString(rest); size > 0 {
+		if _, ok := square(ch); ok {
+			return Entry{}, &ParseError{
+				Line: num,
+				Col:  column(line, end),
+				Msg:  fmt.Sprintf(`unexpected %q after %d squares`, ch, solver.TotalSquares),
+			}
+		}
+	}
+	return Entry{
+		Board:   board,
+		Comment: strings.TrimSpace(rest),
+		Line:    num,
+	}, nil
+}
+
+// CollectionWriter writes puzzles to a collection file one at a time, in the format read by
+// CollectionReader. Its output is buffered, so Flush must be called once every puzzle is written.
+type CollectionWriter struct {
+	w *bufio.Writer
+}
+
+func NewCollectionWriter(w io.Writer) *CollectionWriter {
+	return &CollectionWriter{
+		w: bufio.NewWriter(w),
+	}
+}
+
+// Write writes the entry's puzzle on a line of its own, followed by a space and its comment if it
+// has one. It fails with ErrLineBreak if the comment holds a line break.
+func (cw *CollectionWriter) Write(entry Entry) error {
+	line, err := FormatLine(entry.Board)
+	if err != nil {
+		return err
+	}
+	if strings.ContainsAny(entry.Comment, "\r\n") {
+		return fmt.Errorf(`comment on puzzle: %w`, ErrLineBreak)
+	}
+	if entry.Comment != `` {
+		line += ` ` + entry.Comment
+	}
+	_, err = cw.w.WriteString(line + "\n")
+	return err
+}
+
+// Flush writes any buffered puzzles to the underlying writer.
+func (cw *CollectionWriter) Flush() error {
+	return cw.w.Flush()
+}
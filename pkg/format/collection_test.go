@@ -0,0 +1,197 @@
+package format_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/format"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+const (
+	solvedLine = `483921657967345821251876493548132976729564138136798245372689514814253769695417382`
+	hardLine   = `4.....8.5.3..........7......2.....6.....8.4......1.......6.3.7.5..2.....1.4......`
+)
+
+func TestCollectionReader(t *testing.T) {
+	text := "# a collection of puzzles\n" +
+		"\n" +
+		puzzleLine + "\n" +
+		"  " + hardLine + "\t 7.2 hardest\r\n" +
+		solvedLine + "# solved\n" +
+		solvedLine
+	r := format.NewCollectionReader(strings.NewReader(text))
+
+	var entries []format.Entry
+	for {
+		entry, ok := r.Next()
+		if !ok {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	require.NoError(t, r.Err())
+
+	hard, err := format.ParseLine(hardLine)
+	require.NoError(t, err)
+	solved, err := format.ParseLine(solvedLine)
+	require.NoError(t, err)
+	require.Equal(t, []format.Entry{
+		{Board: puzzle(t), Line: 3},
+		{Board: hard, Comment: `7.2 hardest`, Line: 4},
+		{Board: solved, Comment: `# solved`, Line: 5},
+		{Board: solved, Line: 6},
+	}, entries)
+
+	// the reader stays finished
+	_, ok := r.Next()
+	require.False(t, ok)
+}
+
+func TestCollectionReaderErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		text   string
+		expErr error
+	}{{
+		desc:   `short puzzle`,
+		text:   puzzleLine + "\n" + puzzleLine[:80] + " comment\n",
+		expErr: &format.ParseError{Line: 2, Col: 81, Msg: `unexpected ' '`},
+	}, {
+		desc:   `long puzzle`,
+		text:   puzzleLine + "1\n",
+		expErr: &format.ParseError{Line: 1, Col: 82, Msg: `unexpected '1' after 81 squares`},
+	}, {
+		desc:   `line too long`,
+		text:   puzzleLine + " " + strings.Repeat(`x`, 100000),
+		expErr: bufio.ErrTooLong,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			r := format.NewCollectionReader(strings.NewReader(tc.text))
+			for {
+				if _, ok := r.Next(); !ok {
+					break
+				}
+			}
+			require.Equal(t, tc.expErr, r.Err())
+
+			_, ok := r.Next()
+			require.False(t, ok)
+		})
+	}
+
+	// a reader that fails stops the collection with its error
+	errRead := errors.New(`read failed`)
+	r := format.NewCollectionReader(io.MultiReader(strings.NewReader(puzzleLine+"\n"), &failingReader{err: errRead}))
+	_, ok := r.Next()
+	require.True(t, ok)
+	_, ok = r.Next()
+	require.False(t, ok)
+	require.ErrorIs(t, r.Err(), errRead)
+}
+
+func TestCollectionReaderStreams(t *testing.T) {
+	const total = 10000
+	lines := &lineReader{line: puzzleLine + " rated\n", remaining: total}
+	r := format.NewCollectionReader(lines)
+
+	entry, ok := r.Next()
+	require.True(t, ok)
+	require.Equal(t, `rated`, entry.Comment)
+	// only the start of the collection has been read
+	require.Greater(t, lines.remaining, total/2)
+
+	count := 1
+	for {
+		entry, ok := r.Next()
+		if !ok {
+			break
+		}
+		count++
+		require.Equal(t, count, entry.Line)
+	}
+	require.NoError(t, r.Err())
+	require.Equal(t, total, count)
+}
+
+func TestCollectionWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := format.NewCollectionWriter(&buf)
+	require.NoError(t, w.Write(format.Entry{Board: puzzle(t), Line: 7}))
+	require.NoError(t, w.Write(format.Entry{Board: puzzle(t), Comment: `easy`}))
+	require.ErrorIs(t, w.Write(format.Entry{Board: puzzle(t), Comment: "two\nlines"}), format.ErrLineBreak)
+	require.ErrorIs(t, w.Write(format.Entry{}), solver.ErrWrongNumberOfRows)
+
+	// nothing reaches the underlying writer until it's flushed
+	require.Zero(t, buf.Len())
+	require.NoError(t, w.Flush())
+	require.Equal(t, puzzleLine+"\n"+puzzleLine+" easy\n", buf.String())
+}
+
+func TestCollectionSolving(t *testing.T) {
+	// puzzles can be read, solved and written one at a time
+	in := strings.NewReader(puzzleLine + " first\n" + hardLine + " second\n")
+	var out bytes.Buffer
+	r := format.NewCollectionReader(in)
+	w := format.NewCollectionWriter(&out)
+	for {
+		entry, ok := r.Next()
+		if !ok {
+			break
+		}
+		s, err := solver.New(entry.Board, solver.WithStrategy(solver.MostConstrained))
+		require.NoError(t, err)
+		entry.Board, err = s.Solve()
+		require.NoError(t, err)
+		require.NoError(t, w.Write(entry))
+	}
+	require.NoError(t, r.Err())
+	require.NoError(t, w.Flush())
+
+	r = format.NewCollectionReader(&out)
+	for _, comment := range []string{`first`, `second`} {
+		entry, ok := r.Next()
+		require.True(t, ok)
+		require.Equal(t, comment, entry.Comment)
+		require.Equal(t, solver.TotalSquares, entry.Board.Givens())
+	}
+	_, ok := r.Next()
+	require.False(t, ok)
+	require.NoError(t, r.Err())
+}
+
+// lineReader repeats a line a number of times, producing it only as it's read.
+type lineReader struct {
+	line      string
+	remaining int
+	pending   string
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	if lr.pending == `` {
+		if lr.remaining == 0 {
+			return 0, io.EOF
+		}
+		lr.remaining--
+		lr.pending = lr.line
+	}
+	n := copy(p, lr.pending)
+	lr.pending = lr.pending[n:]
+	return n, nil
+}
+
+type failingReader struct {
+	err error
+}
+
+func (fr *failingReader) Read(p []byte) (int, error) {
+	return 0, fr.err
+}
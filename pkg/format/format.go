@@ -0,0 +1,102 @@
+// Package format reads and writes 9x9 puzzles in the common plain-text formats: a single line of
+// 81 squares, a grid of rows with optional box separators, and SadMan Software's .sdk files. It also
+// streams collection files holding one puzzle per line.
+package format
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// ErrLineBreak reports text that must fit on one line, such as a SadMan header or the comment of a
+// puzzle in a collection, but holds a line break.
+var ErrLineBreak = errors.New(`text contains a line break`)
+
+// ParseError reports where text couldn't be read as a puzzle. Lines and columns count from 1, and
+// columns count characters rather than bytes.
+type ParseError struct {
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+	Msg  string `json:"msg"`
+}
+
+func (pe *ParseError) Error() string {
+	return fmt.Sprintf(`line %d, column %d: %s`, pe.Line, pe.Col, pe.Msg)
+}
+
+// Parse reads a board in any of the package's formats, picking the format from the shape of the
+// text: a single line is read by ParseLine, text with SadMan headers or a [Puzzle] section by
+// ParseSadMan, and anything else by ParseGrid.
+func Parse(text string) (solver.Board, error) {
+	lines := splitLines(text)
+	first, count := -1, 0
+	for i, line := range lines {
+		if strings.TrimSpace(line) == `` {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		count++
+	}
+	switch {
+	case count == 1:
+		return parseLine(lines[first], first+1)
+	case count > 1 && isSadMan(lines[first]):
+		sm, err := ParseSadMan(text)
+		if err != nil {
+			return solver.Board{}, err
+		}
+		return sm.Board, nil
+	default:
+		return ParseGrid(text)
+	}
+}
+
+// splitLines splits text into lines, accepting both \n and \r\n line endings.
+func splitLines(text string) []string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	if len(lines) > 1 && lines[len(lines)-1] == `` {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// column returns the column of the character starting at byte i of the line.
+func column(line string, i int) int {
+	return utf8.RuneCountInString(line[:i]) + 1
+}
+
+// square reads the character for a square, returning false if it isn't Empty or a number.
+func square(ch rune) (int, bool) {
+	switch {
+	case ch == '.' || ch == '0':
+		return solver.Empty, true
+	case ch >= '1' && ch <= '9':
+		return int(ch - '0'), true
+	}
+	return 0, false
+}
+
+// squareChar returns the character written for a square.
+func squareChar(n int) byte {
+	if n == solver.Empty {
+		return '.'
+	}
+	return byte('0' + n)
+}
+
+// checkSize fails unless the board is a standard 9x9 board, which is all the formats can hold.
+func checkSize(board solver.Board) error {
+	if board.Size() != solver.Dimension {
+		return &solver.SizeError{Expected: solver.Dimension, Err: solver.ErrWrongNumberOfRows}
+	}
+	return nil
+}
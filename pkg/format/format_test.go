@@ -0,0 +1,63 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/format"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+	}{{
+		desc: `line`,
+		text: "\n" + puzzleLine + "\n\n",
+	}, {
+		desc: `grid`,
+		text: puzzleGrid,
+	}, {
+		desc: `SadMan`,
+		text: puzzleSadMan,
+	}, {
+		desc: `SadMan without headers`,
+		text: "[Puzzle]\n" + puzzleGrid,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := format.Parse(tc.text)
+			require.NoError(t, err)
+			require.Equal(t, puzzle(t), b)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		text   string
+		expErr *format.ParseError
+	}{{
+		desc:   `line after blank lines`,
+		text:   "\n\n" + puzzleLine[:80] + "\n",
+		expErr: &format.ParseError{Line: 3, Col: 81, Msg: `expected 81 squares, found 80`},
+	}, {
+		desc:   `grid`,
+		text:   "..3|.2.|6..\n9..|3.5|..1\n",
+		expErr: &format.ParseError{Line: 2, Col: 12, Msg: `expected 9 rows, found 2`},
+	}, {
+		desc:   `SadMan`,
+		text:   "#AJane Doe\n#AJohn Doe\n" + puzzleGrid,
+		expErr: &format.ParseError{Line: 2, Col: 1, Msg: `repeated #A header`},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := format.Parse(tc.text)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
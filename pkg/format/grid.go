@@ -0,0 +1,127 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// boxSize is the number of rows and columns in a box of a 9x9 board.
+const boxSize = 3
+
+// ParseGrid reads a board written as 9 rows of 9 squares, as in the SDK and Simple Sudoku (SS)
+// formats. Empty squares are '.' or '0'. Spaces and '|' between squares are ignored, as are blank
+// lines and separator lines made up of '-', '+', '=', '*' and '|'.
+func ParseGrid(text string) (solver.Board, error) {
+	g := newGrid()
+	lines := splitLines(text)
+	for i, line := range lines {
+		if err := g.add(line, i+1); err != nil {
+			return solver.Board{}, err
+		}
+	}
+	return g.finish(lines)
+}
+
+// FormatGrid writes the board as a grid in the Simple Sudoku (SS) style, with '.' for empty
+// squares, '|' between boxes, and a separator line between each band of boxes.
+func FormatGrid(board solver.Board) (string, error) {
+	if err := checkSize(board); err != nil {
+		return ``, err
+	}
+	var sb strings.Builder
+	for r := 0; r < board.Size(); r++ {
+		if r > 0 && r%boxSize == 0 {
+			sb.WriteString("---+---+---\n")
+		}
+		for c := 0; c < board.Size(); c++ {
+			if c > 0 && c%boxSize == 0 {
+				sb.WriteByte('|')
+			}
+			sb.WriteByte(squareChar(board.Get(r, c)))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// grid reads a board one line at a time.
+type grid struct {
+	board solver.Board
+	rows  int
+}
+
+func newGrid() *grid {
+	return &grid{
+		board: solver.NewEmptyBoard(),
+	}
+}
+
+// add reads the next line of the grid, which is either a row of squares or a separator.
+func (g *grid) add(line string, num int) error {
+	if isSeparator(line) {
+		return nil
+	}
+	col, squares := 0, 0
+	for _, ch := range line {
+		col++
+		if ch == '|' || unicode.IsSpace(ch) {
+			continue
+		}
+		if g.rows == solver.Dimension {
+			return &ParseError{
+				Line: num,
+				Col:  col,
+				Msg:  fmt.Sprintf(`unexpected row after %d rows`, solver.Dimension),
+			}
+		}
+		if squares == solver.Dimension {
+			return &ParseError{
+				Line: num,
+				Col:  col,
+				Msg:  fmt.Sprintf(`unexpected %q after %d squares in the row`, ch, solver.Dimension),
+			}
+		}
+		n, ok := square(ch)
+		if !ok {
+			return &ParseError{Line: num, Col: col, Msg: fmt.Sprintf(`unexpected %q`, ch)}
+		}
+		g.board.Set(g.rows, squares, n)
+		squares++
+	}
+	if squares < solver.Dimension {
+		return &ParseError{
+			Line: num,
+			Col:  col + 1,
+			Msg:  fmt.Sprintf(`expected %d squares in the row, found %d`, solver.Dimension, squares),
+		}
+	}
+	g.rows++
+	return nil
+}
+
+// finish returns the board once every line has been read, failing if rows are missing. The error
+// points at the end of the text.
+func (g *grid) finish(lines []string) (solver.Board, error) {
+	if g.rows < solver.Dimension {
+		last := lines[len(lines)-1]
+		return solver.Board{}, &ParseError{
+			Line: len(lines),
+			Col:  column(last, len(last)),
+			Msg:  fmt.Sprintf(`expected %d rows, found %d`, solver.Dimension, g.rows),
+		}
+	}
+	return g.board, nil
+}
+
+// isSeparator reports whether the line holds no squares: it's blank or only draws box borders.
+func isSeparator(line string) bool {
+	for _, ch := range line {
+		if !strings.ContainsRune(`-+=*|`, ch) && !unicode.IsSpace(ch) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,116 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/format"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+const puzzleGrid = `..3|.2.|6..
+9..|3.5|..1
+..1|8.6|4..
+---+---+---
+..8|1.2|9..
+7..|...|..8
+..6|7.8|2..
+---+---+---
+..2|6.9|5..
+8..|2.3|..9
+..5|.1.|3..
+`
+
+func TestParseGrid(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+	}{{
+		desc: `SS`,
+		text: puzzleGrid,
+	}, {
+		desc: `SDK`,
+		text: "..3.2.6..\n9..3.5..1\n..18.64..\n..81.29..\n7.......8\n..67.82..\n..26.95..\n8..2.3..9\n..5.1.3..",
+	}, {
+		desc: `bordered with spaces and zeros`,
+		text: `*-----------*
+ |0 0 3|0 2 0|6 0 0|
+ |9 0 0|3 0 5|0 0 1|
+ |0 0 1|8 0 6|4 0 0|
+ |-----+-----+-----|
+ |0 0 8|1 0 2|9 0 0|
+ |7 0 0|0 0 0|0 0 8|
+ |0 0 6|7 0 8|2 0 0|
+ |=====+=====+=====|
+ |0 0 2|6 0 9|5 0 0|
+ |8 0 0|2 0 3|0 0 9|
+ |0 0 5|0 1 0|3 0 0|
+*-----------*
+
+`,
+	}, {
+		desc: `windows line endings`,
+		text: "..3.2.6..\r\n9..3.5..1\r\n..18.64..\r\n..81.29..\r\n7.......8\r\n..67.82..\r\n..26.95..\r\n8..2.3..9\r\n..5.1.3..\r\n",
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := format.ParseGrid(tc.text)
+			require.NoError(t, err)
+			require.Equal(t, puzzle(t), b)
+		})
+	}
+}
+
+func TestParseGridErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		text   string
+		expErr *format.ParseError
+	}{{
+		desc:   `empty`,
+		text:   ``,
+		expErr: &format.ParseError{Line: 1, Col: 1, Msg: `expected 9 rows, found 0`},
+	}, {
+		desc:   `missing rows`,
+		text:   "..3|.2.|6..\n9..|3.5|..1\n",
+		expErr: &format.ParseError{Line: 2, Col: 12, Msg: `expected 9 rows, found 2`},
+	}, {
+		desc:   `extra row`,
+		text:   puzzleGrid + "\n  .........\n",
+		expErr: &format.ParseError{Line: 13, Col: 3, Msg: `unexpected row after 9 rows`},
+	}, {
+		desc:   `short row`,
+		text:   "..3|.2.|6..\n9..|3.5|..\n",
+		expErr: &format.ParseError{Line: 2, Col: 11, Msg: `expected 9 squares in the row, found 8`},
+	}, {
+		desc:   `long row`,
+		text:   "..3|.2.|6..\n9..|3.5|..1|4\n",
+		expErr: &format.ParseError{Line: 2, Col: 13, Msg: `unexpected '4' after 9 squares in the row`},
+	}, {
+		desc:   `bad character`,
+		text:   "..3|.2.|6..\n9..|3?5|..1\n",
+		expErr: &format.ParseError{Line: 2, Col: 6, Msg: `unexpected '?'`},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := format.ParseGrid(tc.text)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFormatGrid(t *testing.T) {
+	text, err := format.FormatGrid(puzzle(t))
+	require.NoError(t, err)
+	require.Equal(t, puzzleGrid, text)
+
+	b, err := format.ParseGrid(text)
+	require.NoError(t, err)
+	require.Equal(t, puzzle(t), b)
+
+	_, err = format.FormatGrid(solver.Board{})
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// ParseLine reads a board written as a single line of 81 squares, left to right and top to bottom,
+// with '.' or '0' for empty squares. Whitespace around the squares is ignored.
+func ParseLine(line string) (solver.Board, error) {
+	return parseLine(line, 1)
+}
+
+// parseLine is ParseLine for a line that may not be the first of its text, so errors point at the
+// right line.
+func parseLine(line string, num int) (solver.Board, error) {
+	line = strings.TrimRightFunc(line, unicode.IsSpace)
+	board, end, err := readSquares(line, num)
+	if err != nil {
+		return solver.Board{}, err
+	}
+	if end < len(line) {
+		ch, _ := utf8.DecodeRuneInString(line[end:])
+		return solver.Board{}, &ParseError{
+			Line: num,
+			Col:  column(line, end),
+			Msg:  fmt.Sprintf(`unexpected %q after %d squares`, ch, solver.TotalSquares),
+		}
+	}
+	return board, nil
+}
+
+// readSquares reads the 81 squares at the start of a line, after any leading whitespace. It returns
+// the board along with the byte offset where the squares end.
+func readSquares(line string, num int) (solver.Board, int, error) {
+	board := solver.NewEmptyBoard()
+	col, squares := 0, 0
+	for i, ch := range line {
+		if squares == solver.TotalSquares {
+			return board, i, nil
+		}
+		col++
+		if squares == 0 && unicode.IsSpace(ch) {
+			continue
+		}
+		n, ok := square(ch)
+		if !ok {
+			return solver.Board{}, 0, &ParseError{Line: num, Col: col, Msg: fmt.Sprintf(`unexpected %q`, ch)}
+		}
+		board.Set(squares/solver.Dimension, squares%solver.Dimension, n)
+		squares++
+	}
+	if squares < solver.TotalSquares {
+		return solver.Board{}, 0, &ParseError{
+			Line: num,
+			Col:  col + 1,
+			Msg:  fmt.Sprintf(`expected %d squares, found %d`, solver.TotalSquares, squares),
+		}
+	}
+	return board, len(line), nil
+}
+
+// FormatLine writes the board as a single line of 81 squares, with '.' for empty squares.
+func FormatLine(board solver.Board) (string, error) {
+	if err := checkSize(board); err != nil {
+		return ``, err
+	}
+	var sb strings.Builder
+	for r := 0; r < board.Size(); r++ {
+		for c := 0; c < board.Size(); c++ {
+			sb.WriteByte(squareChar(board.Get(r, c)))
+		}
+	}
+	return sb.String(), nil
+}
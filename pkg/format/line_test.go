@@ -0,0 +1,101 @@
+package format_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/format"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+const puzzleLine = `..3.2.6..9..3.5..1..18.64....81.29..7.......8..67.82....26.95..8..2.3..9..5.1.3..`
+
+func puzzle(t *testing.T) solver.Board {
+	b, err := solver.NewBoard([][]int{
+		{0, 0, 3, 0, 2, 0, 6, 0, 0},
+		{9, 0, 0, 3, 0, 5, 0, 0, 1},
+		{0, 0, 1, 8, 0, 6, 4, 0, 0},
+		{0, 0, 8, 1, 0, 2, 9, 0, 0},
+		{7, 0, 0, 0, 0, 0, 0, 0, 8},
+		{0, 0, 6, 7, 0, 8, 2, 0, 0},
+		{0, 0, 2, 6, 0, 9, 5, 0, 0},
+		{8, 0, 0, 2, 0, 3, 0, 0, 9},
+		{0, 0, 5, 0, 1, 0, 3, 0, 0},
+	})
+	require.NoError(t, err)
+	return b
+}
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		desc string
+		line string
+	}{{
+		desc: `dots`,
+		line: puzzleLine,
+	}, {
+		desc: `zeros`,
+		line: strings.ReplaceAll(puzzleLine, `.`, `0`),
+	}, {
+		desc: `surrounding whitespace`,
+		line: "  " + puzzleLine + " \r\n",
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := format.ParseLine(tc.line)
+			require.NoError(t, err)
+			require.Equal(t, puzzle(t), b)
+		})
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		line   string
+		expErr *format.ParseError
+	}{{
+		desc:   `empty`,
+		line:   ``,
+		expErr: &format.ParseError{Line: 1, Col: 1, Msg: `expected 81 squares, found 0`},
+	}, {
+		desc:   `too short`,
+		line:   puzzleLine[:80],
+		expErr: &format.ParseError{Line: 1, Col: 81, Msg: `expected 81 squares, found 80`},
+	}, {
+		desc:   `too long`,
+		line:   puzzleLine + `1`,
+		expErr: &format.ParseError{Line: 1, Col: 82, Msg: `unexpected '1' after 81 squares`},
+	}, {
+		desc:   `bad character`,
+		line:   ` ..3.x` + puzzleLine[5:],
+		expErr: &format.ParseError{Line: 1, Col: 6, Msg: `unexpected 'x'`},
+	}, {
+		desc:   `columns count characters`,
+		line:   `é` + puzzleLine,
+		expErr: &format.ParseError{Line: 1, Col: 1, Msg: `unexpected 'é'`},
+	}, {
+		desc:   `space inside`,
+		line:   puzzleLine[:40] + ` ` + puzzleLine[40:],
+		expErr: &format.ParseError{Line: 1, Col: 41, Msg: `unexpected ' '`},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := format.ParseLine(tc.line)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFormatLine(t *testing.T) {
+	line, err := format.FormatLine(puzzle(t))
+	require.NoError(t, err)
+	require.Equal(t, puzzleLine, line)
+
+	_, err = format.FormatLine(solver.NewEmptyBoard(solver.WithBoxSize(2, 2)))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+}
@@ -0,0 +1,156 @@
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// puzzleSection is the section of a SadMan file holding the puzzle's rows.
+const puzzleSection = `[Puzzle]`
+
+// SadMan is a puzzle in SadMan Software's .sdk format: a board along with optional headers
+// describing it.
+type SadMan struct {
+	Board       solver.Board
+	Author      string
+	Description string
+	Comments    []string
+	Published   string
+	Source      string
+	Level       string
+	URL         string
+}
+
+// headers returns the SadMan header codes along with the fields they fill in, in the order they're
+// written.
+func (sm *SadMan) headers() []header {
+	return []header{
+		{code: 'A', field: &sm.Author},
+		{code: 'D', field: &sm.Description},
+		{code: 'C'},
+		{code: 'B', field: &sm.Published},
+		{code: 'S', field: &sm.Source},
+		{code: 'L', field: &sm.Level},
+		{code: 'U', field: &sm.URL},
+	}
+}
+
+// header is a SadMan header line. Comments have no field since there may be any number of them.
+type header struct {
+	code  byte
+	field *string
+}
+
+// ParseSadMan reads a puzzle in SadMan Software's .sdk format. Header lines start with '#' and a
+// letter: A for the author, D for a description, C for a comment, B for the publication date, S
+// for the source, L for the level and U for the source's URL; headers with other letters are
+// ignored. The board's rows follow as in ParseGrid, under an optional [Puzzle] line. Other
+// sections, such as [State] in saved games, are skipped.
+func ParseSadMan(text string) (SadMan, error) {
+	var sm SadMan
+	seen := make(map[byte]bool)
+	g := newGrid()
+	lines := splitLines(text)
+	inPuzzle, hadPuzzle := true, false
+	for i, line := range lines {
+		num := i + 1
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, `#`):
+			if len(trimmed) < 2 {
+				continue
+			}
+			code, value := trimmed[1], strings.TrimSpace(trimmed[2:])
+			if code == 'C' {
+				sm.Comments = append(sm.Comments, value)
+				continue
+			}
+			for _, h := range sm.headers() {
+				if h.code != code {
+					continue
+				}
+				if seen[code] {
+					return SadMan{}, &ParseError{
+						Line: num,
+						Col:  column(line, strings.Index(line, `#`)),
+						Msg:  fmt.Sprintf(`repeated #%c header`, code),
+					}
+				}
+				seen[code] = true
+				*h.field = value
+			}
+		case strings.HasPrefix(trimmed, `[`):
+			inPuzzle = trimmed == puzzleSection
+			if !inPuzzle {
+				continue
+			}
+			if hadPuzzle || g.rows > 0 {
+				return SadMan{}, &ParseError{
+					Line: num,
+					Col:  column(line, strings.Index(line, `[`)),
+					Msg:  fmt.Sprintf(`repeated %s section`, puzzleSection),
+				}
+			}
+			hadPuzzle = true
+		case inPuzzle:
+			if err := g.add(line, num); err != nil {
+				return SadMan{}, err
+			}
+		}
+	}
+	board, err := g.finish(lines)
+	if err != nil {
+		return SadMan{}, err
+	}
+	sm.Board = board
+	return sm, nil
+}
+
+// FormatSadMan writes the puzzle in SadMan Software's .sdk format, with its headers followed by a
+// [Puzzle] section holding the board's rows. Empty headers are left out. Every header must fit on
+// one line, so it fails with ErrLineBreak if a header holds a line break.
+func FormatSadMan(sm SadMan) (string, error) {
+	if err := checkSize(sm.Board); err != nil {
+		return ``, err
+	}
+	var sb strings.Builder
+	write := func(code byte, value string) error {
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf(`#%c: %w`, code, ErrLineBreak)
+		}
+		fmt.Fprintf(&sb, "#%c%s\n", code, value)
+		return nil
+	}
+	for _, h := range sm.headers() {
+		if h.field == nil {
+			for _, comment := range sm.Comments {
+				if err := write(h.code, comment); err != nil {
+					return ``, err
+				}
+			}
+			continue
+		}
+		if *h.field == `` {
+			continue
+		}
+		if err := write(h.code, *h.field); err != nil {
+			return ``, err
+		}
+	}
+	sb.WriteString(puzzleSection + "\n")
+	for r := 0; r < sm.Board.Size(); r++ {
+		for c := 0; c < sm.Board.Size(); c++ {
+			sb.WriteByte(squareChar(sm.Board.Get(r, c)))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
+
+// isSadMan reports whether the first line of a text marks it as a SadMan file rather than a grid.
+func isSadMan(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, `#`) || strings.HasPrefix(trimmed, `[`)
+}
@@ -0,0 +1,99 @@
+package format_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/format"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+const puzzleSadMan = `#AJane Doe
+#DA gentle warm-up
+#CFirst comment
+#CSecond comment
+#B2021-03-14
+#SThe Daily Grid
+#LEasy
+#Uhttps://example.com/puzzles/1
+[Puzzle]
+..3.2.6..
+9..3.5..1
+..18.64..
+..81.29..
+7.......8
+..67.82..
+..26.95..
+8..2.3..9
+..5.1.3..
+`
+
+func TestParseSadMan(t *testing.T) {
+	sm, err := format.ParseSadMan(puzzleSadMan)
+	require.NoError(t, err)
+	require.Equal(t, format.SadMan{
+		Board:       puzzle(t),
+		Author:      `Jane Doe`,
+		Description: `A gentle warm-up`,
+		Comments:    []string{`First comment`, `Second comment`},
+		Published:   `2021-03-14`,
+		Source:      `The Daily Grid`,
+		Level:       `Easy`,
+		URL:         `https://example.com/puzzles/1`,
+	}, sm)
+
+	// headers and the section line are optional, and other sections are skipped
+	sm, err = format.ParseSadMan("#X unknown header\n#A Jane Doe\n" + puzzleGrid + "[State]\n123456789\n")
+	require.NoError(t, err)
+	require.Equal(t, format.SadMan{Board: puzzle(t), Author: `Jane Doe`}, sm)
+}
+
+func TestParseSadManErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		text   string
+		expErr *format.ParseError
+	}{{
+		desc:   `repeated header`,
+		text:   "#AJane Doe\n #AJohn Doe\n" + puzzleGrid,
+		expErr: &format.ParseError{Line: 2, Col: 2, Msg: `repeated #A header`},
+	}, {
+		desc:   `repeated puzzle section`,
+		text:   "[Puzzle]\n" + puzzleGrid + "[Puzzle]\n",
+		expErr: &format.ParseError{Line: 13, Col: 1, Msg: `repeated [Puzzle] section`},
+	}, {
+		desc:   `bad row`,
+		text:   "#AJane Doe\n[Puzzle]\n..3.2.6..\n9..3.5..1\n..18.64.\n",
+		expErr: &format.ParseError{Line: 5, Col: 9, Msg: `expected 9 squares in the row, found 8`},
+	}, {
+		desc:   `missing rows`,
+		text:   "#AJane Doe\n[Puzzle]\n..3.2.6..\n[State]\n",
+		expErr: &format.ParseError{Line: 4, Col: 8, Msg: `expected 9 rows, found 1`},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := format.ParseSadMan(tc.text)
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
+
+func TestFormatSadMan(t *testing.T) {
+	sm, err := format.ParseSadMan(puzzleSadMan)
+	require.NoError(t, err)
+	text, err := format.FormatSadMan(sm)
+	require.NoError(t, err)
+	require.Equal(t, puzzleSadMan, text)
+
+	text, err = format.FormatSadMan(format.SadMan{Board: puzzle(t)})
+	require.NoError(t, err)
+	require.Equal(t, "[Puzzle]\n"+puzzleSadMan[len(puzzleSadMan)-90:], text)
+
+	_, err = format.FormatSadMan(format.SadMan{Board: puzzle(t), Comments: []string{"two\nlines"}})
+	require.ErrorIs(t, err, format.ErrLineBreak)
+
+	_, err = format.FormatSadMan(format.SadMan{})
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+}
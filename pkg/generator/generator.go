@@ -0,0 +1,160 @@
+// Package generator makes new sudoku puzzles with unique solutions.
+package generator
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// boxSize is the number of rows and columns in a box of a generated board.
+const boxSize = 3
+
+var (
+	ErrInvalidClues = errors.New(`clue limits must satisfy 0 <= min <= max <= 81`)
+	ErrInvalidTier  = errors.New(`unknown difficulty tier`)
+	ErrInvalidSym   = errors.New(`unknown symmetry`)
+	ErrGaveUp       = errors.New(`no puzzle matching the requirements was found`)
+)
+
+// Puzzle is a generated board along with its solution and its difficulty.
+type Puzzle struct {
+	Board    solver.Board
+	Solution solver.Board
+	Rating   solver.Rating
+}
+
+// Generator makes random puzzles. Every puzzle it makes has a unique solution and can be solved
+// without guessing.
+type Generator struct {
+	rng  *rand.Rand
+	opts options
+}
+
+func New(opts ...Option) *Generator {
+	o := newOptions(opts)
+	return &Generator{
+		rng:  rand.New(rand.NewSource(o.seed)),
+		opts: o,
+	}
+}
+
+// Generate makes a new puzzle. It starts from a random complete grid and removes clues in random
+// order, keeping each removal that leaves the puzzle unique, solvable without guessing, and no
+// harder than the requested tier. It tries new grids until the result matches the requirements,
+// and fails with ErrGaveUp once it runs out of attempts.
+func (g *Generator) Generate() (*Puzzle, error) {
+	if err := g.validate(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < g.opts.attempts; i++ {
+		p, err := g.attempt()
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			return p, nil
+		}
+	}
+	return nil, ErrGaveUp
+}
+
+// Minimize removes clues from a board with a unique solution, in random order, until removing any
+// more would make the solution ambiguous. Clues are removed together with every square the
+// generator's symmetry maps them to, so a symmetric board stays symmetric. The result keeps at
+// least the generator's minimum number of clues, but its tier isn't considered.
+func (g *Generator) Minimize(board solver.Board) (solver.Board, error) {
+	if err := g.validate(); err != nil {
+		return solver.Board{}, err
+	}
+	res, _, err := solver.MinimizeGroups(board, g.orbits(), g.hasMinClues)
+	return res, err
+}
+
+// hasMinClues reports whether the board keeps at least the generator's minimum number of clues.
+func (g *Generator) hasMinClues(board solver.Board) bool {
+	return board.Givens() >= g.opts.minClues
+}
+
+func (g *Generator) validate() error {
+	if g.opts.minClues < 0 || g.opts.minClues > g.opts.maxClues || g.opts.maxClues > solver.TotalSquares {
+		return ErrInvalidClues
+	}
+	if g.opts.tier != 0 && (g.opts.tier < solver.Easy || g.opts.tier > solver.Expert) {
+		return ErrInvalidTier
+	}
+	if !g.opts.symmetry.valid() {
+		return ErrInvalidSym
+	}
+	return nil
+}
+
+// orbits returns the squares of the generator's symmetry orbits in random order.
+func (g *Generator) orbits() [][]solver.Cell {
+	orbits := g.opts.symmetry.orbits()
+	res := make([][]solver.Cell, len(orbits))
+	for i, j := range g.rng.Perm(len(orbits)) {
+		res[i] = orbits[j]
+	}
+	return res
+}
+
+// attempt tries to make a puzzle from a new grid. It returns nil if the result doesn't match the
+// requirements.
+func (g *Generator) attempt() (*Puzzle, error) {
+	solution, err := g.grid()
+	if err != nil {
+		return nil, err
+	}
+	rating, err := solver.Rate(solution)
+	if err != nil {
+		return nil, err
+	}
+
+	board, _, err := solver.MinimizeGroups(solution, g.orbits(), func(next solver.Board) bool {
+		if !g.hasMinClues(next) {
+			return false
+		}
+		nextRating, err := solver.Rate(next)
+		if err != nil || !g.allows(nextRating.Tier) {
+			// the puzzle has to stay unique, logical, and easy enough
+			return false
+		}
+		rating = nextRating
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if board.Givens() > g.opts.maxClues || (g.opts.tier != 0 && rating.Tier != g.opts.tier) {
+		return nil, nil
+	}
+	return &Puzzle{
+		Board:    board,
+		Solution: solution,
+		Rating:   rating,
+	}, nil
+}
+
+func (g *Generator) allows(t solver.Tier) bool {
+	return g.opts.tier == 0 || t <= g.opts.tier
+}
+
+// grid returns a random complete grid.
+func (g *Generator) grid() (solver.Board, error) {
+	board := solver.NewEmptyBoard()
+	// the boxes along the diagonal share no rows or columns, so any filling of them can be
+	// completed
+	for b := 0; b < boxSize; b++ {
+		for i, n := range g.rng.Perm(solver.Dimension) {
+			board.Set(boxSize*b+i/boxSize, boxSize*b+i%boxSize, n+1)
+		}
+	}
+	s, err := solver.New(board, solver.WithStrategy(solver.MostConstrained))
+	if err != nil {
+		return solver.Board{}, err
+	}
+	return s.Solve()
+}
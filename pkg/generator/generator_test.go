@@ -0,0 +1,109 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/generator"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		desc string
+		tier solver.Tier
+	}{{
+		desc: `any tier`,
+	}, {
+		desc: `easy`,
+		tier: solver.Easy,
+	}, {
+		desc: `medium`,
+		tier: solver.Medium,
+	}, {
+		desc: `hard`,
+		tier: solver.Hard,
+	}, {
+		desc: `expert`,
+		tier: solver.Expert,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := generator.New(generator.WithSeed(1), generator.WithTier(tc.tier)).Generate()
+			require.NoError(t, err)
+			if tc.tier != 0 {
+				require.Equal(t, tc.tier, p.Rating.Tier)
+			}
+
+			rating, err := solver.Rate(p.Board)
+			require.NoError(t, err)
+			require.Equal(t, p.Rating, rating)
+
+			s, err := solver.New(p.Board)
+			require.NoError(t, err)
+			solved, err := s.Solve()
+			require.NoError(t, err)
+			require.Equal(t, p.Solution, solved)
+		})
+	}
+}
+
+func TestGenerateReproducible(t *testing.T) {
+	p1, err := generator.New(generator.WithSeed(42)).Generate()
+	require.NoError(t, err)
+	p2, err := generator.New(generator.WithSeed(42)).Generate()
+	require.NoError(t, err)
+	require.Equal(t, p1, p2)
+
+	p3, err := generator.New(generator.WithSeed(43)).Generate()
+	require.NoError(t, err)
+	require.NotEqual(t, p1.Board, p3.Board)
+}
+
+func TestGenerateClues(t *testing.T) {
+	g := generator.New(generator.WithSeed(1), generator.WithClues(30, 32))
+	for i := 0; i < 5; i++ {
+		p, err := g.Generate()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, p.Board.Givens(), 30)
+		require.LessOrEqual(t, p.Board.Givens(), 32)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []generator.Option
+		expErr error
+	}{{
+		desc:   `min above max`,
+		opts:   []generator.Option{generator.WithClues(30, 20)},
+		expErr: generator.ErrInvalidClues,
+	}, {
+		desc:   `negative min`,
+		opts:   []generator.Option{generator.WithClues(-1, 20)},
+		expErr: generator.ErrInvalidClues,
+	}, {
+		desc:   `max above board size`,
+		opts:   []generator.Option{generator.WithClues(20, 82)},
+		expErr: generator.ErrInvalidClues,
+	}, {
+		desc:   `unknown tier`,
+		opts:   []generator.Option{generator.WithTier(solver.Expert + 1)},
+		expErr: generator.ErrInvalidTier,
+	}, {
+		desc:   `too few clues`,
+		opts:   []generator.Option{generator.WithClues(0, 16), generator.WithAttempts(3)},
+		expErr: generator.ErrGaveUp,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			opts := append([]generator.Option{generator.WithSeed(1)}, tc.opts...)
+			_, err := generator.New(opts...).Generate()
+			require.Equal(t, tc.expErr, err)
+		})
+	}
+}
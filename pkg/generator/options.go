@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+type options struct {
+	seed     int64
+	tier     solver.Tier
+	minClues int
+	maxClues int
+	attempts int
+	symmetry Symmetry
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		seed:     time.Now().UnixNano(),
+		minClues: 0,
+		maxClues: solver.TotalSquares,
+		attempts: 100,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Option configures a Generator.
+type Option func(*options)
+
+// WithSeed seeds the generator's random numbers, so that generators with the same seed and options
+// generate the same puzzles. The default seed is the current time.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// WithTier makes the generator aim for puzzles of the given difficulty. By default, puzzles can be
+// of any tier.
+func WithTier(t solver.Tier) Option {
+	return func(o *options) {
+		o.tier = t
+	}
+}
+
+// WithClues limits generated puzzles to between min and max clues, inclusive. Very few puzzles
+// with unique solutions have fewer than 22 clues, so low limits may exhaust the generator's
+// attempts.
+func WithClues(min, max int) Option {
+	return func(o *options) {
+		o.minClues = min
+		o.maxClues = max
+	}
+}
+
+// WithAttempts sets how many complete grids the generator tries to make a puzzle from before giving
+// up. The default is 100.
+func WithAttempts(n int) Option {
+	return func(o *options) {
+		o.attempts = n
+	}
+}
+
+// WithSymmetry makes the clues of generated and minimized puzzles keep the symmetry. The default is
+// NoSymmetry.
+func WithSymmetry(sym Symmetry) Option {
+	return func(o *options) {
+		o.symmetry = sym
+	}
+}
@@ -0,0 +1,89 @@
+package generator
+
+import "github.com/cszczepaniak/sudoku-solver/pkg/solver"
+
+// Symmetry is a pattern that the clues of a puzzle keep. Clues are added and removed together with
+// every square that the symmetry maps them to.
+type Symmetry int
+
+const (
+	// NoSymmetry lets clues go anywhere.
+	NoSymmetry Symmetry = iota
+	// Rotational180 keeps the clues the same when the board is turned upside down.
+	Rotational180
+	// Rotational90 keeps the clues the same when the board is turned a quarter turn.
+	Rotational90
+	// Horizontal mirrors the top half of the board onto the bottom half.
+	Horizontal
+	// Vertical mirrors the left half of the board onto the right half.
+	Vertical
+	// Diagonal mirrors the board across the diagonal from its top left to its bottom right.
+	Diagonal
+)
+
+var symmetryNames = map[Symmetry]string{
+	NoSymmetry:    `none`,
+	Rotational180: `180° rotational`,
+	Rotational90:  `90° rotational`,
+	Horizontal:    `horizontal`,
+	Vertical:      `vertical`,
+	Diagonal:      `diagonal`,
+}
+
+func (s Symmetry) String() string {
+	if name, ok := symmetryNames[s]; ok {
+		return name
+	}
+	return `unknown`
+}
+
+func (s Symmetry) valid() bool {
+	_, ok := symmetryNames[s]
+	return ok
+}
+
+// images returns the squares that the symmetry maps (r, c) to, including (r, c) itself.
+func (s Symmetry) images(r, c int) []solver.Cell {
+	last := solver.Dimension - 1
+	res := []solver.Cell{{Row: r, Col: c}}
+	switch s {
+	case Rotational180:
+		res = append(res, solver.Cell{Row: last - r, Col: last - c})
+	case Rotational90:
+		res = append(res,
+			solver.Cell{Row: c, Col: last - r},
+			solver.Cell{Row: last - r, Col: last - c},
+			solver.Cell{Row: last - c, Col: r},
+		)
+	case Horizontal:
+		res = append(res, solver.Cell{Row: last - r, Col: c})
+	case Vertical:
+		res = append(res, solver.Cell{Row: r, Col: last - c})
+	case Diagonal:
+		res = append(res, solver.Cell{Row: c, Col: r})
+	}
+	return res
+}
+
+// orbits splits the board into groups of squares that the symmetry maps onto each other, in
+// reading order of their first squares.
+func (s Symmetry) orbits() [][]solver.Cell {
+	var res [][]solver.Cell
+	seen := make(map[solver.Cell]bool)
+	for r := 0; r < solver.Dimension; r++ {
+		for c := 0; c < solver.Dimension; c++ {
+			if seen[solver.Cell{Row: r, Col: c}] {
+				continue
+			}
+			var orbit []solver.Cell
+			for _, cell := range s.images(r, c) {
+				if !seen[cell] {
+					seen[cell] = true
+					orbit = append(orbit, cell)
+				}
+			}
+			res = append(res, orbit)
+		}
+	}
+	return res
+}
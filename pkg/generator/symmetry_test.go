@@ -0,0 +1,113 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/generator"
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+var symmetries = []struct {
+	sym    generator.Symmetry
+	mirror func(r, c int) (int, int)
+}{{
+	sym:    generator.NoSymmetry,
+	mirror: func(r, c int) (int, int) { return r, c },
+}, {
+	sym:    generator.Rotational180,
+	mirror: func(r, c int) (int, int) { return 8 - r, 8 - c },
+}, {
+	sym:    generator.Rotational90,
+	mirror: func(r, c int) (int, int) { return c, 8 - r },
+}, {
+	sym:    generator.Horizontal,
+	mirror: func(r, c int) (int, int) { return 8 - r, c },
+}, {
+	sym:    generator.Vertical,
+	mirror: func(r, c int) (int, int) { return r, 8 - c },
+}, {
+	sym:    generator.Diagonal,
+	mirror: func(r, c int) (int, int) { return c, r },
+}}
+
+func TestGenerateSymmetric(t *testing.T) {
+	for _, tc := range symmetries {
+		tc := tc
+		t.Run(tc.sym.String(), func(t *testing.T) {
+			p, err := generator.New(generator.WithSeed(1), generator.WithSymmetry(tc.sym)).Generate()
+			require.NoError(t, err)
+			requireSymmetric(t, p.Board, tc.mirror)
+
+			s, err := solver.New(p.Board)
+			require.NoError(t, err)
+			require.True(t, s.IsUnique())
+		})
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	p, err := generator.New(generator.WithSeed(1)).Generate()
+	require.NoError(t, err)
+
+	for _, tc := range symmetries {
+		tc := tc
+		t.Run(tc.sym.String(), func(t *testing.T) {
+			res, err := generator.New(generator.WithSeed(1), generator.WithSymmetry(tc.sym)).Minimize(p.Solution)
+			require.NoError(t, err)
+			requireSymmetric(t, res, tc.mirror)
+
+			s, err := solver.New(res)
+			require.NoError(t, err)
+			require.True(t, s.IsUnique())
+			solved, err := s.Solve()
+			require.NoError(t, err)
+			require.Equal(t, p.Solution, solved)
+
+			// every remaining clue is needed along with its mirror images
+			for r := 0; r < res.Size(); r++ {
+				for c := 0; c < res.Size(); c++ {
+					if res.Get(r, c) == solver.Empty {
+						continue
+					}
+					without := res
+					for mr, mc := r, c; ; {
+						without.Set(mr, mc, solver.Empty)
+						mr, mc = tc.mirror(mr, mc)
+						if mr == r && mc == c {
+							break
+						}
+					}
+					s, err := solver.New(without)
+					require.NoError(t, err)
+					require.False(t, s.IsUnique(), `clue at (%d, %d) is redundant`, r, c)
+				}
+			}
+		})
+	}
+}
+
+func TestMinimizeErrors(t *testing.T) {
+	_, err := generator.New().Minimize(solver.NewEmptyBoard())
+	require.Equal(t, solver.ErrMultipleSolutions, err)
+
+	_, err = generator.New().Minimize(solver.NewEmptyBoard(solver.WithBoxSize(2, 2)))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+
+	_, err = generator.New(generator.WithSymmetry(generator.Diagonal + 1)).Minimize(solver.NewEmptyBoard())
+	require.Equal(t, generator.ErrInvalidSym, err)
+}
+
+// requireSymmetric checks that the board has a clue wherever mirror maps one of its clues to.
+func requireSymmetric(t *testing.T, board solver.Board, mirror func(r, c int) (int, int)) {
+	for r := 0; r < board.Size(); r++ {
+		for c := 0; c < board.Size(); c++ {
+			if board.Get(r, c) == solver.Empty {
+				continue
+			}
+			mr, mc := mirror(r, c)
+			require.NotEqual(t, solver.Empty, board.Get(mr, mc), `clue at (%d, %d) has no mirror image`, r, c)
+		}
+	}
+}
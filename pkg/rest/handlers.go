@@ -1,38 +1,158 @@
 package rest
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
 )
 
+// solve responds with the solution of the board. A stats=true parameter responds with an object
+// holding the solution and the statistics of the search instead.
 func (s *Server) solve(c *gin.Context) {
+	stats, ok := boolQuery(c, `stats`)
+	if !ok {
+		return
+	}
+	opts, ok := s.solveOptions(c)
+	if !ok {
+		return
+	}
 	input, ok := bindSudokuBoard(c)
 	if !ok {
 		return
 	}
-	solver, err := solver.New(input)
+	solver, err := solver.New(input, opts...)
 	if err != nil {
 		writeErrorResponse(c, http.StatusBadRequest, err)
 		return
 	}
-	solution, err := solver.Solve()
+	ctx, cancel := context.WithTimeout(c.Request.Context(), s.solveTimeout)
+	defer cancel()
+	solution, err := solver.SolveContext(ctx)
 	if err != nil {
-		writeErrorResponse(c, http.StatusBadRequest, err)
+		writeErrorResponse(c, searchErrorStatus(err), err)
+		return
+	}
+	if stats {
+		c.JSON(http.StatusOK, gin.H{
+			`solution`: solution,
+			`stats`:    solver.Stats(),
+		})
 		return
 	}
 	c.JSON(http.StatusOK, solution)
 }
 
-func bindSudokuBoard(c *gin.Context) ([][]int, bool) {
+// hint responds with the easiest logical step available from the board. Checking that the board
+// has a unique solution gives up after the same timeout as solving it.
+func (s *Server) hint(c *gin.Context) {
+	opts, ok := s.solveOptions(c)
+	if !ok {
+		return
+	}
+	input, ok := bindSudokuBoard(c)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), s.solveTimeout)
+	defer cancel()
+	step, err := solver.HintContext(ctx, input, opts...)
+	if err != nil {
+		writeErrorResponse(c, searchErrorStatus(err), err)
+		return
+	}
+	c.JSON(http.StatusOK, step)
+}
+
+// candidates responds with the candidates of every square. A prune=true parameter applies the
+// basic eliminations first: pointing pairs, box/line reductions, and naked and hidden subsets.
+func (s *Server) candidates(c *gin.Context) {
+	prune, ok := boolQuery(c, `prune`)
+	if !ok {
+		return
+	}
+	opts, ok := s.solveOptions(c)
+	if !ok {
+		return
+	}
+	input, ok := bindSudokuBoard(c)
+	if !ok {
+		return
+	}
+	sol, err := solver.New(input, opts...)
+	if err != nil {
+		writeErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if prune {
+		c.JSON(http.StatusOK, sol.PrunedCandidates(solver.HiddenTriple))
+		return
+	}
+	c.JSON(http.StatusOK, sol.Candidates())
+}
+
+// solveOptions reads the variant rules requested in the query string and limits the search to the
+// server's node budget. Searches fill in forced moves first, which keeps them well within the
+// server's limits. A diagonal=true parameter solves under Sudoku-X rules.
+func (s *Server) solveOptions(c *gin.Context) ([]solver.Option, bool) {
+	diagonal, ok := boolQuery(c, `diagonal`)
+	if !ok {
+		return nil, false
+	}
+	opts := []solver.Option{
+		solver.WithStrategy(solver.MostConstrained),
+		solver.WithNodeBudget(s.nodeBudget),
+	}
+	if diagonal {
+		opts = append(opts, solver.WithDiagonals())
+	}
+	return opts, true
+}
+
+// boolQuery reads an optional true or false query parameter. It writes an error response if the
+// parameter is malformed.
+func boolQuery(c *gin.Context, key string) (bool, bool) {
+	v, ok := c.GetQuery(key)
+	if !ok {
+		return false, true
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		writeErrorResponse(c, http.StatusBadRequest, fmt.Errorf(`invalid %s parameter %q`, key, v))
+		return false, false
+	}
+	return b, true
+}
+
+// bindSudokuBoard reads a board from the request body. It writes an error response if the body
+// isn't an array of rows or the rows don't make up a valid board.
+func bindSudokuBoard(c *gin.Context) (solver.Board, bool) {
 	var input [][]int
 	if err := c.BindJSON(&input); err != nil {
 		writeErrorResponse(c, http.StatusBadRequest, err)
-		return nil, false
+		return solver.Board{}, false
+	}
+	board, err := solver.NewBoard(input)
+	if err != nil {
+		writeErrorResponse(c, http.StatusBadRequest, err)
+		return solver.Board{}, false
+	}
+	return board, true
+}
+
+// searchErrorStatus returns the status code for an error from a search. Searches that give up are
+// the server's limits at work rather than a problem with the request.
+func searchErrorStatus(err error) int {
+	if errors.Is(err, solver.ErrCanceled) || errors.Is(err, solver.ErrBudgetExceeded) {
+		return http.StatusServiceUnavailable
 	}
-	return input, true
+	return http.StatusBadRequest
 }
 
 func writeErrorResponse(c *gin.Context, code int, err error) {
@@ -42,6 +162,9 @@ func writeErrorResponse(c *gin.Context, code int, err error) {
 	switch terr := err.(type) {
 	case *solver.InvalidBoardError:
 		resp[`invalidSquares`] = terr.InvalidSquares
+		if len(terr.InvalidCages) != 0 {
+			resp[`invalidCages`] = terr.InvalidCages
+		}
 	default:
 	}
 	c.JSON(code, resp)
@@ -185,6 +185,183 @@ func TestSolve(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, http.StatusOK, res.StatusCode)
 	compareResponse(t, solved, res.Body)
+
+	res, err = http.Post(url+`?stats=true`, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	var withStats struct {
+		Solution [][]int      `json:"solution"`
+		Stats    solver.Stats `json:"stats"`
+	}
+	require.NoError(t, json.Unmarshal(readBytes(t, res.Body), &withStats))
+	require.Equal(t, solved, withStats.Solution)
+	require.GreaterOrEqual(t, withStats.Stats.Nodes, 41)
+	require.Len(t, withStats.Stats.Guesses, solver.Dimension)
+
+	res, err = http.Post(url+`?stats=maybe`, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	compareResponse(t, gin.H{`error`: `invalid stats parameter "maybe"`}, res.Body)
+}
+
+func TestSolveDiagonal(t *testing.T) {
+	ts := httptest.NewServer(NewServer())
+	defer ts.Close()
+	url := ts.URL + `/api/solve`
+
+	board := [][]int{
+		{0, 0, 0, 0, 5, 0, 0, 0, 8},
+		{0, 0, 0, 0, 6, 0, 7, 0, 0},
+		{0, 5, 0, 1, 0, 8, 0, 0, 0},
+		{2, 0, 1, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 3, 0, 0, 0, 0},
+		{5, 0, 0, 0, 0, 0, 9, 3, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 0, 0, 0, 6},
+		{0, 0, 0, 9, 2, 4, 0, 0, 0},
+	}
+	solved := [][]int{
+		{4, 6, 3, 7, 5, 2, 1, 9, 8},
+		{1, 9, 8, 4, 6, 3, 7, 5, 2},
+		{7, 5, 2, 1, 9, 8, 4, 6, 3},
+		{2, 3, 1, 6, 4, 9, 8, 7, 5},
+		{8, 4, 9, 5, 3, 7, 6, 2, 1},
+		{5, 7, 6, 2, 8, 1, 9, 3, 4},
+		{3, 2, 7, 8, 1, 6, 5, 4, 9},
+		{9, 1, 4, 3, 7, 5, 2, 8, 6},
+		{6, 8, 5, 9, 2, 4, 3, 1, 7},
+	}
+	res, err := http.Post(url+`?diagonal=true`, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	compareResponse(t, solved, res.Body)
+
+	res, err = http.Post(url+`?diagonal=maybe`, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	compareResponse(t, gin.H{`error`: `invalid diagonal parameter "maybe"`}, res.Body)
+}
+
+func TestSolveLimits(t *testing.T) {
+	board := [][]int{
+		{4, 0, 0, 0, 0, 0, 8, 0, 5},
+		{0, 3, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 7, 0, 0, 0, 0, 0},
+		{0, 2, 0, 0, 0, 0, 0, 6, 0},
+		{0, 0, 0, 0, 8, 0, 4, 0, 0},
+		{0, 0, 0, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 6, 0, 3, 0, 7, 0},
+		{5, 0, 0, 2, 0, 0, 0, 0, 0},
+		{1, 0, 4, 0, 0, 0, 0, 0, 0},
+	}
+	tests := []struct {
+		desc   string
+		setup  func(s *Server)
+		path   string
+		expErr string
+	}{{
+		desc:   `solve out of budget`,
+		setup:  func(s *Server) { s.nodeBudget = 10 },
+		path:   `/api/solve`,
+		expErr: solver.ErrBudgetExceeded.Error(),
+	}, {
+		desc:   `hint out of budget`,
+		setup:  func(s *Server) { s.nodeBudget = 10 },
+		path:   `/api/hint`,
+		expErr: solver.ErrBudgetExceeded.Error(),
+	}, {
+		desc:   `solve out of time`,
+		setup:  func(s *Server) { s.solveTimeout = 0 },
+		path:   `/api/solve`,
+		expErr: `solving was canceled: context deadline exceeded`,
+	}, {
+		desc:   `hint out of time`,
+		setup:  func(s *Server) { s.solveTimeout = 0 },
+		path:   `/api/hint`,
+		expErr: `solving was canceled: context deadline exceeded`,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			srv := NewServer()
+			tc.setup(srv)
+			ts := httptest.NewServer(srv)
+			defer ts.Close()
+
+			res, err := http.Post(ts.URL+tc.path, `application/json`, boardToReader(t, board))
+			require.NoError(t, err)
+			require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+			compareResponse(t, gin.H{`error`: tc.expErr}, res.Body)
+		})
+	}
+}
+
+func TestHint(t *testing.T) {
+	ts := httptest.NewServer(NewServer())
+	defer ts.Close()
+	url := ts.URL + `/api/hint`
+
+	board := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 0},
+	}
+	res, err := http.Post(url, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	compareResponse(t, solver.Step{
+		Technique:  solver.NakedSingle,
+		Cells:      []solver.Cell{{Row: 8, Col: 8}},
+		Placements: []solver.Candidate{{Row: 8, Col: 8, Digit: 1}},
+	}, res.Body)
+
+	board[8][8] = 1
+	res, err = http.Post(url, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	compareResponse(t, gin.H{`error`: solver.ErrAlreadySolved.Error()}, res.Body)
+}
+
+func TestCandidates(t *testing.T) {
+	ts := httptest.NewServer(NewServer())
+	defer ts.Close()
+	url := ts.URL + `/api/candidates`
+
+	board := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 0, 0},
+	}
+	exp := make([][][]int, 9)
+	for i := range exp {
+		exp[i] = make([][]int, 9)
+	}
+	exp[8][7] = []int{9}
+	exp[8][8] = []int{1}
+
+	for _, query := range []string{``, `?prune=true`} {
+		res, err := http.Post(url+query, `application/json`, boardToReader(t, board))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		compareResponse(t, exp, res.Body)
+	}
+
+	res, err := http.Post(url+`?prune=sure`, `application/json`, boardToReader(t, board))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	compareResponse(t, gin.H{`error`: `invalid prune parameter "sure"`}, res.Body)
 }
 
 func boardToReader(t *testing.T, b [][]int) io.Reader {
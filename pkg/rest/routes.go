@@ -9,6 +9,8 @@ import (
 func (s *Server) AddEndpoints(eng *gin.Engine) {
 	api := s.eng.Group(`/api`)
 	api.POST(`/solve`, s.solve)
+	api.POST(`/hint`, s.hint)
+	api.POST(`/candidates`, s.candidates)
 	api.GET(`/health`, func(c *gin.Context) {
 		c.String(http.StatusOK, `Healthy!`)
 	})
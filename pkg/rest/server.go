@@ -2,6 +2,7 @@ package rest
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -10,15 +11,27 @@ import (
 
 var _ http.Handler = (*Server)(nil)
 
+const (
+	// defaultSolveTimeout is how long a request may spend solving a board.
+	defaultSolveTimeout = 5 * time.Second
+	// defaultNodeBudget is how many guesses a request may make while solving a board.
+	defaultNodeBudget = 10000000
+)
+
 type Server struct {
 	eng *gin.Engine
+
+	solveTimeout time.Duration
+	nodeBudget   int
 }
 
 func NewServer() *Server {
 	eng := gin.Default()
 	eng.Use(cors.AllowAll())
 	s := &Server{
-		eng: eng,
+		eng:          eng,
+		solveTimeout: defaultSolveTimeout,
+		nodeBudget:   defaultNodeBudget,
 	}
 	s.AddEndpoints(eng)
 	return s
@@ -0,0 +1,199 @@
+package solver
+
+import "testing"
+
+// escargot is a puzzle that makes the sequential search backtrack a lot.
+var escargot = [][]int{
+	{1, 0, 0, 0, 0, 7, 0, 9, 0},
+	{0, 3, 0, 0, 2, 0, 0, 0, 8},
+	{0, 0, 9, 6, 0, 0, 5, 0, 0},
+	{0, 0, 5, 3, 0, 0, 9, 0, 0},
+	{0, 1, 0, 0, 8, 0, 0, 0, 2},
+	{6, 0, 0, 0, 0, 4, 0, 0, 0},
+	{3, 0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 4, 0, 0, 0, 0, 0, 0, 7},
+	{0, 0, 7, 0, 0, 0, 3, 0, 0},
+}
+
+func escargotBoard(b *testing.B) Board {
+	board, err := NewBoard(escargot)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return board
+}
+
+func BenchmarkSolve(b *testing.B) {
+	board := escargotBoard(b)
+	strategies := []struct {
+		name string
+		opts []Option
+	}{{
+		name: `sequential`,
+		opts: []Option{WithStrategy(Sequential)},
+	}, {
+		name: `most constrained`,
+		opts: []Option{WithStrategy(MostConstrained)},
+	}, {
+		name: `parallel`,
+		opts: []Option{WithStrategy(MostConstrained), WithParallelism(4)},
+	}}
+	for _, bc := range strategies {
+		bc := bc
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				s, err := New(board, bc.opts...)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err := s.Solve(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// entryCache is the part of the puzzle cache the search relies on. It lets the benchmark below run
+// the same search against the bitmask cache and the map-backed cache it replaced.
+type entryCache interface {
+	add(r, c, n int)
+	remove(r, c, n int)
+	isValidEntry(r, c, n int) bool
+}
+
+func BenchmarkPuzzleCache(b *testing.B) {
+	caches := []struct {
+		name     string
+		newCache func() entryCache
+	}{{
+		name:     `bitmask`,
+		newCache: func() entryCache { return newPuzzleCache(newGeometry(3, 3)) },
+	}, {
+		name:     `map`,
+		newCache: func() entryCache { return newMapPuzzleCache() },
+	}}
+	for _, bc := range caches {
+		bc := bc
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var nums [TotalSquares]int
+				pc := bc.newCache()
+				for r, row := range escargot {
+					for c, n := range row {
+						nums[r*Dimension+c] = n
+						pc.add(r, c, n)
+					}
+				}
+				if !benchSearch(&nums, pc, 0) {
+					b.Fatal(`expected a solution`)
+				}
+			}
+		})
+	}
+}
+
+func benchSearch(nums *[TotalSquares]int, pc entryCache, start int) bool {
+	for idx := start; idx < TotalSquares; idx++ {
+		if nums[idx] != Empty {
+			continue
+		}
+		r, c := idx/Dimension, idx%Dimension
+		for n := MinEntry; n <= MaxEntry; n++ {
+			if !pc.isValidEntry(r, c, n) {
+				continue
+			}
+			nums[idx] = n
+			pc.add(r, c, n)
+			if benchSearch(nums, pc, idx+1) {
+				return true
+			}
+			nums[idx] = Empty
+			pc.remove(r, c, n)
+		}
+		return false
+	}
+	return true
+}
+
+// mapPuzzleCache is the map-backed cache the solver used before switching to bitmasks.
+type mapPuzzleCache struct {
+	g     *geometry
+	rows  []mapPointCache
+	cols  []mapPointCache
+	boxes []mapPointCache
+}
+
+func newMapPuzzleCache() *mapPuzzleCache {
+	pc := &mapPuzzleCache{
+		g:     newGeometry(3, 3),
+		rows:  make([]mapPointCache, Dimension),
+		cols:  make([]mapPointCache, Dimension),
+		boxes: make([]mapPointCache, Dimension),
+	}
+	for i := 0; i < Dimension; i++ {
+		pc.rows[i] = make(mapPointCache)
+		pc.cols[i] = make(mapPointCache)
+		pc.boxes[i] = make(mapPointCache)
+	}
+	return pc
+}
+
+func (pc *mapPuzzleCache) add(r, c, n int) {
+	if n == 0 {
+		return
+	}
+	pt := pc.g.point(r, c)
+	pc.rows[pt.row].add(pt, n)
+	pc.cols[pt.col].add(pt, n)
+	pc.boxes[pt.box].add(pt, n)
+}
+
+func (pc *mapPuzzleCache) remove(r, c, n int) {
+	pt := pc.g.point(r, c)
+	pc.rows[pt.row].remove(pt, n)
+	pc.cols[pt.col].remove(pt, n)
+	pc.boxes[pt.box].remove(pt, n)
+}
+
+func (pc *mapPuzzleCache) isValidEntry(r, c, n int) bool {
+	pt := pc.g.point(r, c)
+	return pc.rows[pt.row].isValidEntry(n) &&
+		pc.cols[pt.col].isValidEntry(n) &&
+		pc.boxes[pt.box].isValidEntry(n)
+}
+
+type mapPointCache map[int]map[point]struct{}
+
+func (pc mapPointCache) add(pt point, n int) {
+	ptSet, ok := pc[n]
+	if !ok {
+		ptSet = make(map[point]struct{})
+		pc[n] = ptSet
+	}
+	ptSet[pt] = struct{}{}
+}
+
+func (pc mapPointCache) remove(pt point, n int) {
+	delete(pc[n], pt)
+}
+
+func (pc mapPointCache) isValidEntry(n int) bool {
+	return len(pc[n]) == 0
+}
+
+func BenchmarkDLXSolve(b *testing.B) {
+	board := escargotBoard(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d, err := NewDLX(board)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := d.Solve(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
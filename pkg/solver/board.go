@@ -0,0 +1,138 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Board is a square sudoku board with up to MaxDimension rows and columns, holding Empty or a
+// number from 1 to its size in each square. A Board is a value: assigning or passing one copies
+// it, so changes to one copy never show up in another. The zero Board has no squares.
+type Board struct {
+	dim   int
+	cells [MaxDimension * MaxDimension]uint8
+}
+
+// NewBoard converts rows of numbers into a board. There must be between 1 and MaxDimension rows,
+// each as long as there are rows, and every number must be Empty or between 1 and the number of
+// rows. It fails with a SizeError if the rows have the wrong shape, and with an InvalidBoardError
+// listing every square whose number is out of range.
+func NewBoard(rows [][]int) (Board, error) {
+	var b Board
+	switch {
+	case len(rows) == 0:
+		return b, &SizeError{Expected: Dimension, Err: ErrWrongNumberOfRows}
+	case len(rows) != Dimension && len(rows[0]) != len(rows) && len(rows[0]) > 0 && len(rows[0]) <= MaxDimension:
+		// unless there are the usual number of rows, a first row of a different length most likely
+		// means a row is missing or there's one too many
+		return b, &SizeError{Expected: len(rows[0]), Err: ErrWrongNumberOfRows}
+	case len(rows) > MaxDimension:
+		return b, &SizeError{Expected: MaxDimension, Err: ErrWrongNumberOfRows}
+	}
+	b.dim = len(rows)
+	var errs []*InvalidSquareError
+	for r, row := range rows {
+		if len(row) != b.dim {
+			return Board{}, &SizeError{Expected: b.dim, Err: ErrWrongNumberOfCols}
+		}
+		for c, n := range row {
+			if n < Empty || n > b.dim {
+				errs = append(errs, newInvalidSquareError(r, c, outOfRange))
+				continue
+			}
+			b.cells[r*b.dim+c] = uint8(n)
+		}
+	}
+	if len(errs) != 0 {
+		return Board{}, &InvalidBoardError{InvalidSquares: errs}
+	}
+	return b, nil
+}
+
+// Size returns the number of rows and columns of the board.
+func (b Board) Size() int {
+	return b.dim
+}
+
+// Get returns the number at row r and column c, or Empty. It panics if the square is off the
+// board.
+func (b Board) Get(r, c int) int {
+	return int(b.cells[b.index(r, c)])
+}
+
+// Set writes n at row r and column c. Setting Empty clears the square. It panics if the square is
+// off the board or n is out of range.
+func (b *Board) Set(r, c, n int) {
+	idx := b.index(r, c)
+	if n < Empty || n > b.dim {
+		panic(fmt.Sprintf(`number %d out of range for a %dx%d board`, n, b.dim, b.dim))
+	}
+	b.cells[idx] = uint8(n)
+}
+
+// Clone returns a copy of the board. It's the same as assigning the board to a new variable.
+func (b Board) Clone() Board {
+	return b
+}
+
+// Equal reports whether the boards are the same size and hold the same numbers.
+func (b Board) Equal(other Board) bool {
+	return b == other
+}
+
+// Givens returns the number of filled-in squares, which for a puzzle are its clues.
+func (b Board) Givens() int {
+	count := 0
+	for _, n := range b.cells[:b.dim*b.dim] {
+		if n != Empty {
+			count++
+		}
+	}
+	return count
+}
+
+// Rows converts the board into rows of numbers. The rows are a copy and may be modified freely.
+func (b Board) Rows() [][]int {
+	res := make([][]int, b.dim)
+	for r := range res {
+		res[r] = make([]int, b.dim)
+		for c := range res[r] {
+			res[r][c] = int(b.cells[r*b.dim+c])
+		}
+	}
+	return res
+}
+
+// MarshalJSON encodes the board as an array of rows.
+func (b Board) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.Rows())
+}
+
+// UnmarshalJSON decodes a board from an array of rows, failing the same way as NewBoard.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var rows [][]int
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	res, err := NewBoard(rows)
+	if err != nil {
+		return err
+	}
+	*b = res
+	return nil
+}
+
+func (b *Board) index(r, c int) int {
+	if r < 0 || r >= b.dim || c < 0 || c >= b.dim {
+		panic(fmt.Sprintf(`square (%d, %d) is off the %dx%d board`, r, c, b.dim, b.dim))
+	}
+	return r*b.dim + c
+}
+
+// valueAt returns the number at the cell, treating cells off the board as empty.
+func valueAt(b *Board, cell Cell) int {
+	if cell.Row < 0 || cell.Row >= b.dim || cell.Col < 0 || cell.Col >= b.dim {
+		return Empty
+	}
+	return int(b.cells[cell.Row*b.dim+cell.Col])
+}
@@ -0,0 +1,207 @@
+package solver_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestNewBoard(t *testing.T) {
+	rows := [][]int{
+		{1, 0, 0, 0},
+		{0, 0, 3, 0},
+		{0, 4, 0, 0},
+		{0, 0, 0, 2},
+	}
+	b, err := solver.NewBoard(rows)
+	require.NoError(t, err)
+	require.Equal(t, 4, b.Size())
+	require.Equal(t, 4, b.Givens())
+	require.Equal(t, rows, b.Rows())
+	for r, row := range rows {
+		for c, n := range row {
+			require.Equal(t, n, b.Get(r, c))
+		}
+	}
+
+	// the board doesn't share the rows it was made from
+	rows[0][0] = 2
+	require.Equal(t, 1, b.Get(0, 0))
+
+	require.Zero(t, solver.Board{}.Size())
+	require.Empty(t, solver.Board{}.Rows())
+}
+
+func TestNewBoardErrors(t *testing.T) {
+	tests := []struct {
+		desc        string
+		rows        [][]int
+		expErr      error
+		expExpected int
+		expSquares  []*solver.InvalidSquareError
+	}{{
+		desc:        `no rows`,
+		rows:        [][]int{},
+		expErr:      solver.ErrWrongNumberOfRows,
+		expExpected: solver.Dimension,
+	}, {
+		desc:        `too many rows`,
+		rows:        make([][]int, solver.MaxDimension+1),
+		expErr:      solver.ErrWrongNumberOfRows,
+		expExpected: solver.MaxDimension,
+	}, {
+		desc:        `ragged rows`,
+		rows:        [][]int{{1, 2}, {3}},
+		expErr:      solver.ErrWrongNumberOfCols,
+		expExpected: 2,
+	}, {
+		desc:        `one row`,
+		rows:        [][]int{{1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		expErr:      solver.ErrWrongNumberOfRows,
+		expExpected: 9,
+	}, {
+		desc:        `extra row`,
+		rows:        append(solver.NewEmptyBoard().Rows(), make([]int, 9)),
+		expErr:      solver.ErrWrongNumberOfRows,
+		expExpected: 9,
+	}, {
+		desc:        `short rows`,
+		rows:        [][]int{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}},
+		expErr:      solver.ErrWrongNumberOfCols,
+		expExpected: 9,
+	}, {
+		desc:        `long first row`,
+		rows:        [][]int{{1, 2, 3}, {3, 4}},
+		expErr:      solver.ErrWrongNumberOfRows,
+		expExpected: 3,
+	}, {
+		desc: `numbers out of range`,
+		rows: [][]int{
+			{5, 0, 0, 0},
+			{0, 0, 0, 0},
+			{0, 0, -1, 0},
+			{0, 0, 0, 4},
+		},
+		expSquares: []*solver.InvalidSquareError{{
+			Row: 0,
+			Col: 0,
+			Msg: `number out of range`,
+		}, {
+			Row: 2,
+			Col: 2,
+			Msg: `number out of range`,
+		}},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := solver.NewBoard(tc.rows)
+			require.Error(t, err)
+			require.Equal(t, solver.Board{}, b)
+			if tc.expSquares != nil {
+				var ibe *solver.InvalidBoardError
+				require.True(t, errors.As(err, &ibe))
+				require.Equal(t, tc.expSquares, ibe.InvalidSquares)
+				return
+			}
+			require.ErrorIs(t, err, tc.expErr)
+			var se *solver.SizeError
+			require.True(t, errors.As(err, &se))
+			require.Equal(t, tc.expExpected, se.Expected)
+		})
+	}
+}
+
+func TestBoardSet(t *testing.T) {
+	b := solver.NewEmptyBoard(solver.WithBoxSize(2, 2))
+	require.Equal(t, 4, b.Size())
+	require.Zero(t, b.Givens())
+
+	b.Set(1, 2, 4)
+	require.Equal(t, 4, b.Get(1, 2))
+	require.Equal(t, 1, b.Givens())
+	b.Set(1, 2, solver.Empty)
+	require.Equal(t, solver.Empty, b.Get(1, 2))
+	require.Zero(t, b.Givens())
+
+	require.Panics(t, func() { b.Get(4, 0) })
+	require.Panics(t, func() { b.Get(0, -1) })
+	require.Panics(t, func() { b.Set(0, 4, 1) })
+	require.Panics(t, func() { b.Set(0, 0, 5) })
+	require.Panics(t, func() { b.Set(0, 0, -1) })
+	require.Panics(t, func() { solver.Board{}.Get(0, 0) })
+}
+
+func TestBoardCopies(t *testing.T) {
+	b := mustBoard(t, [][]int{
+		{1, 0, 0, 0},
+		{0, 0, 3, 0},
+		{0, 4, 0, 0},
+		{0, 0, 0, 2},
+	})
+
+	clone := b.Clone()
+	assigned := b
+	require.True(t, b.Equal(clone))
+	require.True(t, b.Equal(assigned))
+
+	clone.Set(0, 1, 2)
+	assigned.Set(0, 2, 2)
+	require.Equal(t, solver.Empty, b.Get(0, 1))
+	require.Equal(t, solver.Empty, b.Get(0, 2))
+	require.False(t, b.Equal(clone))
+	require.False(t, clone.Equal(assigned))
+
+	rows := b.Rows()
+	rows[1][1] = 2
+	require.Equal(t, solver.Empty, b.Get(1, 1))
+
+	// boards of different sizes are never equal, even when both are empty
+	require.False(t, solver.NewEmptyBoard().Equal(solver.NewEmptyBoard(solver.WithBoxSize(2, 2))))
+}
+
+func TestBoardJSON(t *testing.T) {
+	rows := [][]int{
+		{1, 0, 0, 0},
+		{0, 0, 3, 0},
+		{0, 4, 0, 0},
+		{0, 0, 0, 2},
+	}
+	b := mustBoard(t, rows)
+
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+	require.JSONEq(t, `[[1,0,0,0],[0,0,3,0],[0,4,0,0],[0,0,0,2]]`, string(data))
+
+	var decoded solver.Board
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, b, decoded)
+
+	tests := []struct {
+		desc string
+		data string
+	}{{
+		desc: `not rows`,
+		data: `{"board": 1}`,
+	}, {
+		desc: `no rows`,
+		data: `[]`,
+	}, {
+		desc: `ragged rows`,
+		data: `[[1, 2], [3]]`,
+	}, {
+		desc: `number out of range`,
+		data: `[[1, 2], [3, 4]]`,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			var b solver.Board
+			require.Error(t, json.Unmarshal([]byte(tc.data), &b))
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package solver
+
+import "fmt"
+
+// Cage is a group of squares, as in killer sudoku, whose numbers must add up to Sum without
+// repeating.
+type Cage struct {
+	Cells []Cell `json:"cells"`
+	Sum   int    `json:"sum"`
+}
+
+// InvalidCageError reports a cage that can't be part of a valid puzzle. Cage is the cage's index
+// in the order the cages were given to WithCages.
+type InvalidCageError struct {
+	Cage int    `json:"cage"`
+	Msg  string `json:"msg,omitempty"`
+}
+
+func newInvalidCageError(cage int, reason invalidReason) *InvalidCageError {
+	return &InvalidCageError{
+		Cage: cage,
+		Msg:  reasonToMsg[reason],
+	}
+}
+
+func (ice *InvalidCageError) Error() string {
+	return fmt.Sprintf(`invalid cage %d: %s`, ice.Cage, ice.Msg)
+}
+
+// validateCages reports cages that are malformed or that overlap another cage. Each cage is
+// reported at most once, for the first problem found with it.
+func validateCages(g *geometry, cages []Cage) []*InvalidCageError {
+	errs := make(map[int]*InvalidCageError)
+	owners := make(map[Cell]int)
+	for i, cage := range cages {
+		if reason := cageShapeReason(g, cage); reason != 0 {
+			errs[i] = newInvalidCageError(i, reason)
+			continue
+		}
+		for _, cell := range cage.Cells {
+			owner, ok := owners[cell]
+			if !ok {
+				owners[cell] = i
+				continue
+			}
+			for _, j := range []int{owner, i} {
+				if _, ok := errs[j]; !ok {
+					errs[j] = newInvalidCageError(j, overlappingCage)
+				}
+			}
+		}
+	}
+
+	res := make([]*InvalidCageError, 0, len(errs))
+	for i := range cages {
+		if err, ok := errs[i]; ok {
+			res = append(res, err)
+		}
+	}
+	return res
+}
+
+func cageShapeReason(g *geometry, cage Cage) invalidReason {
+	if len(cage.Cells) == 0 {
+		return emptyCage
+	}
+	if len(cage.Cells) > g.dim {
+		return cageTooLarge
+	}
+	seen := make(map[Cell]bool, len(cage.Cells))
+	for _, cell := range cage.Cells {
+		if cell.Row < 0 || cell.Row >= g.dim || cell.Col < 0 || cell.Col >= g.dim {
+			return cageOffBoard
+		}
+		if seen[cell] {
+			return cageRepeatsSquare
+		}
+		seen[cell] = true
+	}
+	if lo, hi := sumBounds(g.dim, 0, len(cage.Cells)); cage.Sum < lo || cage.Sum > hi {
+		return impossibleCageSum
+	}
+	return 0
+}
+
+// sumBounds returns the smallest and largest sums of k distinct numbers from 1 to dim that aren't
+// in used. If there aren't k such numbers, the smallest sum is greater than the largest.
+func sumBounds(dim int, used digitSet, k int) (int, int) {
+	lo, hi := 0, 0
+	for n, left := MinEntry, k; left > 0; n++ {
+		if n > dim {
+			return 1, 0
+		}
+		if !used.has(n) {
+			lo += n
+			left--
+		}
+	}
+	for n, left := dim, k; left > 0; n-- {
+		if !used.has(n) {
+			hi += n
+			left--
+		}
+	}
+	return lo, hi
+}
+
+// cageConstraint keeps the numbers in each cage distinct and prunes any number that would leave
+// the rest of its cage unable to reach the cage's sum.
+type cageConstraint struct {
+	cages []Cage
+	dim   int
+
+	// cageOf holds the cage each square is in, or -1, indexed like Solver.nums.
+	cageOf []int
+	sums   []int
+	filled []int
+	used   []digitSet
+}
+
+// newCageConstraint builds a constraint for cages that have passed validateCages.
+func newCageConstraint(g *geometry, cages []Cage) *cageConstraint {
+	cc := &cageConstraint{
+		cages:  cages,
+		dim:    g.dim,
+		cageOf: make([]int, g.dim*g.dim),
+		sums:   make([]int, len(cages)),
+		filled: make([]int, len(cages)),
+		used:   make([]digitSet, len(cages)),
+	}
+	for i := range cc.cageOf {
+		cc.cageOf[i] = -1
+	}
+	for i, cage := range cages {
+		for _, cell := range cage.Cells {
+			cc.cageOf[cell.Row*g.dim+cell.Col] = i
+		}
+	}
+	return cc
+}
+
+func (cc *cageConstraint) cageAt(r, c int) int {
+	if r < 0 || r >= cc.dim || c < 0 || c >= cc.dim {
+		return -1
+	}
+	return cc.cageOf[r*cc.dim+c]
+}
+
+func (cc *cageConstraint) Allows(r, c, n int) bool {
+	i := cc.cageAt(r, c)
+	if i < 0 {
+		return true
+	}
+	if cc.used[i].has(n) {
+		return false
+	}
+	need := cc.cages[i].Sum - cc.sums[i] - n
+	left := len(cc.cages[i].Cells) - cc.filled[i] - 1
+	if left == 0 {
+		return need == 0
+	}
+	lo, hi := sumBounds(cc.dim, cc.used[i]|digitBit(n), left)
+	return need >= lo && need <= hi
+}
+
+func (cc *cageConstraint) Place(r, c, n int) {
+	if i := cc.cageAt(r, c); i >= 0 {
+		cc.sums[i] += n
+		cc.filled[i]++
+		cc.used[i] |= digitBit(n)
+	}
+}
+
+func (cc *cageConstraint) Clear(r, c, n int) {
+	if i := cc.cageAt(r, c); i >= 0 {
+		cc.sums[i] -= n
+		cc.filled[i]--
+		cc.used[i] &^= digitBit(n)
+	}
+}
+
+func (cc *cageConstraint) Violations(board Board) []*InvalidSquareError {
+	var errs []*InvalidSquareError
+	for _, cage := range cc.cages {
+		var seen, dups digitSet
+		for _, cell := range cage.Cells {
+			if n := valueAt(&board, cell); n != Empty {
+				if seen.has(n) {
+					dups |= digitBit(n)
+				}
+				seen |= digitBit(n)
+			}
+		}
+		for _, cell := range cage.Cells {
+			if dups.has(valueAt(&board, cell)) {
+				errs = append(errs, newInvalidSquareError(cell.Row, cell.Col, duplicateInCage))
+			}
+		}
+	}
+	return errs
+}
+
+// sumViolations reports cages whose numbers already exceed their sum, or that are full and don't
+// add up to it.
+func (cc *cageConstraint) sumViolations(board *Board) []*InvalidCageError {
+	var errs []*InvalidCageError
+	for i, cage := range cc.cages {
+		sum, filled := 0, 0
+		for _, cell := range cage.Cells {
+			if n := valueAt(board, cell); n != Empty {
+				sum += n
+				filled++
+			}
+		}
+		switch {
+		case sum > cage.Sum:
+			errs = append(errs, newInvalidCageError(i, cageOverSum))
+		case filled == len(cage.Cells) && sum != cage.Sum:
+			errs = append(errs, newInvalidCageError(i, cageSumMismatch))
+		}
+	}
+	return errs
+}
+
+func (cc *cageConstraint) Clone() Constraint {
+	clone := *cc
+	clone.sums = append([]int(nil), cc.sums...)
+	clone.filled = append([]int(nil), cc.filled...)
+	clone.used = append([]digitSet(nil), cc.used...)
+	return &clone
+}
@@ -0,0 +1,209 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// dominoCages covers the board with two-square cages whose sums come from solution.
+func dominoCages(solution [][]int) []solver.Cage {
+	var cages []solver.Cage
+	for r := 0; r < 9; r++ {
+		for c := 0; c < 8; c += 2 {
+			cages = append(cages, solver.Cage{
+				Cells: []solver.Cell{{Row: r, Col: c}, {Row: r, Col: c + 1}},
+				Sum:   solution[r][c] + solution[r][c+1],
+			})
+		}
+	}
+	for r := 0; r < 8; r += 2 {
+		cages = append(cages, solver.Cage{
+			Cells: []solver.Cell{{Row: r, Col: 8}, {Row: r + 1, Col: 8}},
+			Sum:   solution[r][8] + solution[r+1][8],
+		})
+	}
+	return append(cages, solver.Cage{
+		Cells: []solver.Cell{{Row: 8, Col: 8}},
+		Sum:   solution[8][8],
+	})
+}
+
+func TestSolveKiller(t *testing.T) {
+	solved := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	input := solver.NewEmptyBoard()
+	input.Set(0, 0, 7)
+	input.Set(0, 4, 1)
+	input.Set(0, 6, 3)
+	input.Set(2, 6, 7)
+
+	s, err := solver.New(input, solver.WithCages(dominoCages(solved)...), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+}
+
+func TestInvalidCages(t *testing.T) {
+	cell := func(r, c int) solver.Cell { return solver.Cell{Row: r, Col: c} }
+	tests := []struct {
+		desc       string
+		board      func() solver.Board
+		cages      []solver.Cage
+		expSquares []*solver.InvalidSquareError
+		expCages   []*solver.InvalidCageError
+	}{{
+		desc: `malformed cages`,
+		cages: []solver.Cage{{
+			Cells: nil,
+			Sum:   5,
+		}, {
+			Cells: []solver.Cell{cell(0, 0), cell(0, 9)},
+			Sum:   5,
+		}, {
+			Cells: []solver.Cell{cell(1, 1), cell(1, 1)},
+			Sum:   5,
+		}, {
+			Cells: []solver.Cell{cell(2, 2), cell(2, 3)},
+			Sum:   2,
+		}, {
+			Cells: []solver.Cell{cell(3, 3), cell(3, 4)},
+			Sum:   18,
+		}, {
+			Cells: []solver.Cell{
+				cell(4, 0), cell(4, 1), cell(4, 2), cell(4, 3), cell(4, 4),
+				cell(4, 5), cell(4, 6), cell(4, 7), cell(4, 8), cell(5, 0),
+			},
+			Sum: 50,
+		}},
+		expCages: []*solver.InvalidCageError{{
+			Cage: 0,
+			Msg:  `cage has no squares`,
+		}, {
+			Cage: 1,
+			Msg:  `cage has a square off the board`,
+		}, {
+			Cage: 2,
+			Msg:  `cage lists a square more than once`,
+		}, {
+			Cage: 3,
+			Msg:  `no distinct numbers fill the cage to its sum`,
+		}, {
+			Cage: 4,
+			Msg:  `no distinct numbers fill the cage to its sum`,
+		}, {
+			Cage: 5,
+			Msg:  `cage has more squares than there are numbers`,
+		}},
+	}, {
+		desc: `overlapping cages`,
+		cages: []solver.Cage{{
+			Cells: []solver.Cell{cell(0, 0), cell(0, 1)},
+			Sum:   5,
+		}, {
+			Cells: []solver.Cell{cell(5, 5)},
+			Sum:   5,
+		}, {
+			Cells: []solver.Cell{cell(0, 1), cell(1, 1)},
+			Sum:   5,
+		}},
+		expCages: []*solver.InvalidCageError{{
+			Cage: 0,
+			Msg:  `cage overlaps another cage`,
+		}, {
+			Cage: 2,
+			Msg:  `cage overlaps another cage`,
+		}},
+	}, {
+		desc: `numbers don't fit the sums`,
+		board: func() solver.Board {
+			b := solver.NewEmptyBoard()
+			b.Set(0, 0, 5)
+			b.Set(0, 1, 4)
+			b.Set(1, 0, 3)
+			b.Set(1, 1, 2)
+			b.Set(2, 0, 1)
+			b.Set(3, 0, 2)
+			b.Set(4, 0, 2)
+			return b
+		},
+		cages: []solver.Cage{{
+			Cells: []solver.Cell{cell(0, 0), cell(0, 1), cell(0, 2)},
+			Sum:   8,
+		}, {
+			Cells: []solver.Cell{cell(1, 0), cell(1, 1)},
+			Sum:   8,
+		}, {
+			Cells: []solver.Cell{cell(2, 0), cell(2, 1)},
+			Sum:   8,
+		}, {
+			Cells: []solver.Cell{cell(3, 0), cell(4, 0), cell(3, 1)},
+			Sum:   12,
+		}},
+		expSquares: []*solver.InvalidSquareError{{
+			Row: 3,
+			Col: 0,
+			Msg: `duplicate number in row, column, or box`,
+		}, {
+			Row: 4,
+			Col: 0,
+			Msg: `duplicate number in row, column, or box`,
+		}},
+		expCages: []*solver.InvalidCageError{{
+			Cage: 0,
+			Msg:  `numbers exceed the cage's sum`,
+		}, {
+			Cage: 1,
+			Msg:  `numbers don't add up to the cage's sum`,
+		}},
+	}, {
+		desc: `duplicate number in cage`,
+		board: func() solver.Board {
+			b := solver.NewEmptyBoard()
+			b.Set(0, 0, 3)
+			b.Set(1, 4, 3)
+			return b
+		},
+		cages: []solver.Cage{{
+			Cells: []solver.Cell{cell(0, 0), cell(0, 1), cell(1, 1), cell(1, 2), cell(1, 3), cell(1, 4)},
+			Sum:   30,
+		}},
+		expSquares: []*solver.InvalidSquareError{{
+			Row: 0,
+			Col: 0,
+			Msg: `duplicate number in cage`,
+		}, {
+			Row: 1,
+			Col: 4,
+			Msg: `duplicate number in cage`,
+		}},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			board := solver.NewEmptyBoard()
+			if tc.board != nil {
+				board = tc.board()
+			}
+			_, err := solver.New(board, solver.WithCages(tc.cages...))
+			require.IsType(t, &solver.InvalidBoardError{}, err)
+			ibe := err.(*solver.InvalidBoardError)
+			require.ElementsMatch(t, tc.expSquares, ibe.InvalidSquares)
+			require.Equal(t, tc.expCages, ibe.InvalidCages)
+		})
+	}
+}
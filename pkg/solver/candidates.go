@@ -0,0 +1,44 @@
+package solver
+
+// Candidates returns the numbers that each empty square could hold without breaking any of the
+// board's rules, in increasing order and indexed by row and column. Filled squares have none.
+func (s *Solver) Candidates() [][][]int {
+	return newLogicSolver(s).candidateGrid()
+}
+
+// PrunedCandidates returns the candidates left once every technique from PointingPair up to and
+// including hardest stops eliminating any. Singles are left to the player, since they place
+// numbers rather than eliminate candidates. The board itself isn't changed.
+func (s *Solver) PrunedCandidates(hardest Technique) [][][]int {
+	ls := newLogicSolver(s)
+	for {
+		step, ok := ls.nextElimination(hardest)
+		if !ok {
+			return ls.candidateGrid()
+		}
+		ls.apply(step)
+	}
+}
+
+// nextElimination returns a step using the easiest eliminating technique no harder than hardest,
+// or false if none makes progress.
+func (ls *logicSolver) nextElimination(hardest Technique) (Step, bool) {
+	for t := PointingPair; t <= hardest && t <= XYWing; t++ {
+		if step, ok := ls.find(t); ok {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+func (ls *logicSolver) candidateGrid() [][][]int {
+	dim := ls.s.geo.dim
+	res := make([][][]int, dim)
+	for r := range res {
+		res[r] = make([][]int, dim)
+		for c := range res[r] {
+			res[r][c] = ls.cands[r*dim+c].digits()
+		}
+	}
+	return res
+}
@@ -0,0 +1,74 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestCandidates(t *testing.T) {
+	input := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{0, 0, 0, 9, 6, 7, 4, 2, 3},
+		{0, 0, 0, 8, 2, 5, 6, 9, 1},
+	}
+	s, err := newSolver(input)
+	require.NoError(t, err)
+
+	cands := s.Candidates()
+	require.Len(t, cands, 9)
+	for r, row := range cands {
+		require.Len(t, row, 9)
+		for c, nums := range row {
+			if r < 7 || c > 2 {
+				require.Empty(t, nums)
+			}
+		}
+	}
+	require.Equal(t, []int{8}, cands[7][0])
+	require.Equal(t, []int{1}, cands[7][1])
+	require.Equal(t, []int{5}, cands[7][2])
+	require.Equal(t, []int{4}, cands[8][0])
+	require.Equal(t, []int{7}, cands[8][1])
+	require.Equal(t, []int{3}, cands[8][2])
+
+	// finding candidates leaves the board alone
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+}
+
+func TestPrunedCandidates(t *testing.T) {
+	input := hardBoard()
+	s, err := newSolver(input, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	solved, err := s.Solve()
+	require.NoError(t, err)
+
+	s, err = newSolver(input)
+	require.NoError(t, err)
+	cands := s.Candidates()
+	pruned := s.PrunedCandidates(solver.HiddenTriple)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+
+	removed := 0
+	for r, row := range pruned {
+		for c, nums := range row {
+			require.Subset(t, cands[r][c], nums)
+			removed += len(cands[r][c]) - len(nums)
+			if input[r][c] == solver.Empty {
+				require.Contains(t, nums, solved.Get(r, c))
+			}
+		}
+	}
+	require.NotZero(t, removed)
+
+	// without any eliminating techniques, nothing is pruned
+	require.Equal(t, cands, s.PrunedCandidates(solver.HiddenSingle))
+}
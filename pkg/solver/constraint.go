@@ -0,0 +1,158 @@
+package solver
+
+// Cell identifies a square on the board.
+type Cell struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+// Constraint is a rule that a solution has to follow. The classic rules are built-in constraints;
+// more can be added to a Solver with WithConstraints to solve variants.
+//
+// The solver reports every number it writes to or clears from the board, so a constraint can keep
+// whatever bookkeeping it needs to answer Allows quickly.
+type Constraint interface {
+	// Allows reports whether n can be written at (r, c) given the numbers placed so far.
+	Allows(r, c, n int) bool
+	// Place records that n was written at (r, c).
+	Place(r, c, n int)
+	// Clear records that n was removed from (r, c).
+	Clear(r, c, n int)
+	// Violations reports the squares of board that break the rule. It's used to validate boards
+	// before solving, so it must not assume that the board was built with Place.
+	Violations(board Board) []*InvalidSquareError
+	// Clone returns an independent copy of the constraint, including its bookkeeping.
+	Clone() Constraint
+}
+
+// maskedConstraint is implemented by constraints that can narrow down a square's candidates all at
+// once rather than checking one number at a time. The solver prefers it over Allows.
+type maskedConstraint interface {
+	Constraint
+	filter(r, c int, cands digitSet) digitSet
+}
+
+// AllDifferent returns a constraint requiring that no number appears twice within any of the
+// given units.
+func AllDifferent(units ...[]Cell) Constraint {
+	return newUnitConstraint(units, duplicateNumber)
+}
+
+// unitConstraint keeps the numbers within each of its units distinct. It's the constraint behind
+// the classic rules, with the board's rows, columns, and boxes as its units.
+type unitConstraint struct {
+	units  [][]Cell
+	reason invalidReason
+
+	// width is one more than the largest column in any unit.
+	width int
+	// unitsOf holds the units each square is in, indexed by r*width + c.
+	unitsOf [][]int
+	// present holds the numbers written in each unit.
+	present []digitSet
+}
+
+func newUnitConstraint(units [][]Cell, reason invalidReason) *unitConstraint {
+	uc := &unitConstraint{
+		units:   units,
+		reason:  reason,
+		present: make([]digitSet, len(units)),
+	}
+	height := 0
+	for _, unit := range units {
+		for _, cell := range unit {
+			if offBoard(cell) {
+				continue
+			}
+			if cell.Col >= uc.width {
+				uc.width = cell.Col + 1
+			}
+			if cell.Row >= height {
+				height = cell.Row + 1
+			}
+		}
+	}
+	uc.unitsOf = make([][]int, height*uc.width)
+	for i, unit := range units {
+		for _, cell := range unit {
+			if offBoard(cell) {
+				// no number can ever be written there, so the cell never affects the unit
+				continue
+			}
+			idx := cell.Row*uc.width + cell.Col
+			uc.unitsOf[idx] = append(uc.unitsOf[idx], i)
+		}
+	}
+	return uc
+}
+
+// offBoard reports whether the cell is above or left of every board.
+func offBoard(cell Cell) bool {
+	return cell.Row < 0 || cell.Col < 0
+}
+
+func (uc *unitConstraint) unitsAt(r, c int) []int {
+	if r < 0 || c < 0 || c >= uc.width || r*uc.width+c >= len(uc.unitsOf) {
+		return nil
+	}
+	return uc.unitsOf[r*uc.width+c]
+}
+
+func (uc *unitConstraint) Allows(r, c, n int) bool {
+	for _, u := range uc.unitsAt(r, c) {
+		if uc.present[u].has(n) {
+			return false
+		}
+	}
+	return true
+}
+
+func (uc *unitConstraint) filter(r, c int, cands digitSet) digitSet {
+	for _, u := range uc.unitsAt(r, c) {
+		cands &^= uc.present[u]
+	}
+	return cands
+}
+
+func (uc *unitConstraint) Place(r, c, n int) {
+	for _, u := range uc.unitsAt(r, c) {
+		uc.present[u] |= digitBit(n)
+	}
+}
+
+func (uc *unitConstraint) Clear(r, c, n int) {
+	for _, u := range uc.unitsAt(r, c) {
+		uc.present[u] &^= digitBit(n)
+	}
+}
+
+func (uc *unitConstraint) Violations(board Board) []*InvalidSquareError {
+	var errs []*InvalidSquareError
+	for _, unit := range uc.units {
+		var seen, dups digitSet
+		for _, cell := range unit {
+			if n := valueAt(&board, cell); n != Empty {
+				if seen.has(n) {
+					dups |= digitBit(n)
+				}
+				seen |= digitBit(n)
+			}
+		}
+		if dups == 0 {
+			continue
+		}
+		for _, cell := range unit {
+			if dups.has(valueAt(&board, cell)) {
+				errs = append(errs, newInvalidSquareError(cell.Row, cell.Col, uc.reason))
+			}
+		}
+	}
+	return errs
+}
+
+func (uc *unitConstraint) Clone() Constraint {
+	clone := *uc
+	clone.present = make([]digitSet, len(uc.present))
+	copy(clone.present, uc.present)
+	return &clone
+}
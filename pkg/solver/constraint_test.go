@@ -0,0 +1,124 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// oddSquares is a stateless constraint requiring odd numbers in some squares.
+type oddSquares map[solver.Cell]bool
+
+func (os oddSquares) Allows(r, c, n int) bool {
+	return !os[solver.Cell{Row: r, Col: c}] || n%2 == 1
+}
+
+func (os oddSquares) Place(r, c, n int) {}
+func (os oddSquares) Clear(r, c, n int) {}
+
+func (os oddSquares) Violations(board solver.Board) []*solver.InvalidSquareError {
+	var errs []*solver.InvalidSquareError
+	for cell := range os {
+		if n := board.Get(cell.Row, cell.Col); n != solver.Empty && n%2 == 0 {
+			errs = append(errs, &solver.InvalidSquareError{
+				Row: cell.Row,
+				Col: cell.Col,
+				Msg: `number must be odd`,
+			})
+		}
+	}
+	return errs
+}
+
+func (os oddSquares) Clone() solver.Constraint {
+	return os
+}
+
+func TestConstraintsAreApplied(t *testing.T) {
+	odd := oddSquares{{Row: 0, Col: 0}: true, {Row: 3, Col: 3}: true}
+	// the diagonal from top left to bottom right has to hold different numbers too
+	diagonal := solver.AllDifferent([]solver.Cell{{Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 2, Col: 2}, {Row: 3, Col: 3}})
+
+	opts := []solver.Option{
+		solver.WithBoxSize(2, 2),
+		solver.WithConstraints(odd, diagonal),
+	}
+	empty := solver.NewEmptyBoard(opts...)
+	s, err := solver.New(empty, opts...)
+	require.NoError(t, err)
+
+	count := 0
+	sols := s.Solutions()
+	for sol, ok := sols.Next(); ok; sol, ok = sols.Next() {
+		count++
+		requireSolution(t, 2, 2, empty, sol)
+		require.Equal(t, 1, sol.Get(0, 0)%2)
+		require.Equal(t, 1, sol.Get(3, 3)%2)
+		require.ElementsMatch(t, []int{1, 2, 3, 4}, []int{sol.Get(0, 0), sol.Get(1, 1), sol.Get(2, 2), sol.Get(3, 3)})
+	}
+	require.NotZero(t, count)
+
+	// the solver works with clones, so the same constraints can be used again; with every square on
+	// the diagonal odd, there's no way for it to hold four different numbers
+	for i := 0; i < 4; i++ {
+		odd[solver.Cell{Row: i, Col: i}] = true
+	}
+	s, err = solver.New(empty, solver.WithBoxSize(2, 2), solver.WithConstraints(odd, diagonal))
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.Equal(t, solver.ErrNoSolution, err)
+}
+
+func TestConstraintViolations(t *testing.T) {
+	input := solver.NewEmptyBoard()
+	input.Set(0, 0, 2)
+	input.Set(4, 4, 5)
+	input.Set(8, 8, 5)
+	input.Set(0, 8, 7)
+
+	diagonal := make([]solver.Cell, 0, solver.Dimension)
+	for i := 0; i < solver.Dimension; i++ {
+		diagonal = append(diagonal, solver.Cell{Row: i, Col: i})
+	}
+	_, err := solver.New(
+		input,
+		solver.WithConstraints(oddSquares{{Row: 0, Col: 0}: true, {Row: 0, Col: 8}: true}),
+		solver.WithConstraints(solver.AllDifferent(diagonal)),
+	)
+	require.IsType(t, &solver.InvalidBoardError{}, err)
+	require.ElementsMatch(t, []*solver.InvalidSquareError{{
+		Row: 0,
+		Col: 0,
+		Msg: `number must be odd`,
+	}, {
+		Row: 4,
+		Col: 4,
+		Msg: `duplicate number in row, column, or box`,
+	}, {
+		Row: 8,
+		Col: 8,
+		Msg: `duplicate number in row, column, or box`,
+	}}, err.(*solver.InvalidBoardError).InvalidSquares)
+}
+
+func TestAllDifferentOffBoard(t *testing.T) {
+	// cells off the board never hold a number, so they're ignored
+	unit := []solver.Cell{{Row: -1}, {Col: -1}, {Row: 0, Col: 0}, {Row: 1, Col: 1}, {Row: 9, Col: 9}}
+	opts := []solver.Option{
+		solver.WithBoxSize(2, 2),
+		solver.WithConstraints(solver.AllDifferent(unit)),
+	}
+	empty := solver.NewEmptyBoard(opts...)
+	s, err := solver.New(empty, opts...)
+	require.NoError(t, err)
+	sol, err := s.Solve()
+	require.NoError(t, err)
+	require.NotEqual(t, sol.Get(0, 0), sol.Get(1, 1))
+}
+
+func TestDLXRejectsConstraints(t *testing.T) {
+	_, err := solver.NewDLX(solver.NewEmptyBoard(), solver.WithConstraints(oddSquares{}))
+	require.Equal(t, solver.ErrUnsupportedConstraints, err)
+}
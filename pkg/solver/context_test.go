@@ -0,0 +1,104 @@
+package solver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestSolveContextCanceled(t *testing.T) {
+	input := multiSolutionBoard()
+	s, err := newSolver(input)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.SolveContext(ctx)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+
+	solved, err := s.SolveContext(context.Background())
+	require.NoError(t, err)
+	require.NotEqual(t, mustBoard(t, input), solved)
+}
+
+func TestCountSolutionsContextDeadline(t *testing.T) {
+	// an empty board has far too many solutions to count them all
+	s, err := solver.New(solver.NewEmptyBoard())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	count, err := s.CountSolutionsContext(ctx, 0)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Greater(t, count, 0)
+	require.Equal(t, solver.NewEmptyBoard(), s.ToBoard())
+}
+
+func TestUniqueSearchesCanceled(t *testing.T) {
+	board := mustBoard(t, hardBoard())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := solver.HintContext(ctx, board)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	_, err = solver.RateContext(ctx, board)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	_, _, err = solver.MinimizeContext(ctx, board)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	_, _, err = solver.MinimizeGroupsContext(ctx, board, [][]solver.Cell{{{Row: 0, Col: 0}}}, nil)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+}
+
+func TestNodeBudget(t *testing.T) {
+	input := hardBoard()
+
+	s, err := newSolver(input, solver.WithNodeBudget(10))
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+
+	unique, err := s.IsUniqueContext(context.Background())
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+	require.False(t, unique)
+
+	_, err = solver.Rate(mustBoard(t, input), solver.WithNodeBudget(10))
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+
+	// the budget is plenty when the search fills in forced moves first
+	s, err = newSolver(input, solver.WithNodeBudget(100000), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	unique, err = s.IsUniqueContext(context.Background())
+	require.NoError(t, err)
+	require.True(t, unique)
+}
+
+func TestSolutionsErr(t *testing.T) {
+	s, err := newSolver(multiSolutionBoard(), solver.WithNodeBudget(5))
+	require.NoError(t, err)
+
+	sols := s.Solutions()
+	for {
+		if _, ok := sols.Next(); !ok {
+			break
+		}
+	}
+	require.Equal(t, solver.ErrBudgetExceeded, sols.Err())
+
+	s, err = newSolver(multiSolutionBoard())
+	require.NoError(t, err)
+	sols = s.Solutions()
+	for {
+		if _, ok := sols.Next(); !ok {
+			break
+		}
+	}
+	require.NoError(t, sols.Err())
+}
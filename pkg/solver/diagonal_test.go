@@ -0,0 +1,83 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestSolveDiagonals(t *testing.T) {
+	input := [][]int{
+		{0, 0, 0, 0, 5, 0, 0, 0, 8},
+		{0, 0, 0, 0, 6, 0, 7, 0, 0},
+		{0, 5, 0, 1, 0, 8, 0, 0, 0},
+		{2, 0, 1, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 3, 0, 0, 0, 0},
+		{5, 0, 0, 0, 0, 0, 9, 3, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 0, 0, 0, 6},
+		{0, 0, 0, 9, 2, 4, 0, 0, 0},
+	}
+	solved := [][]int{
+		{4, 6, 3, 7, 5, 2, 1, 9, 8},
+		{1, 9, 8, 4, 6, 3, 7, 5, 2},
+		{7, 5, 2, 1, 9, 8, 4, 6, 3},
+		{2, 3, 1, 6, 4, 9, 8, 7, 5},
+		{8, 4, 9, 5, 3, 7, 6, 2, 1},
+		{5, 7, 6, 2, 8, 1, 9, 3, 4},
+		{3, 2, 7, 8, 1, 6, 5, 4, 9},
+		{9, 1, 4, 3, 7, 5, 2, 8, 6},
+		{6, 8, 5, 9, 2, 4, 3, 1, 7},
+	}
+
+	// without the diagonals the puzzle is ambiguous
+	s, err := newSolver(input, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.False(t, s.IsUnique())
+
+	s, err = newSolver(input, solver.WithDiagonals(), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+
+	d, err := solver.NewDLX(mustBoard(t, input), solver.WithDiagonals())
+	require.NoError(t, err)
+	require.Equal(t, 1, d.CountSolutions(0))
+	actual, err = d.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+}
+
+func TestDiagonalDuplicates(t *testing.T) {
+	input := solver.NewEmptyBoard()
+	// a duplicate on the main diagonal only
+	input.Set(1, 1, 4)
+	input.Set(7, 7, 4)
+	// a duplicate on the anti-diagonal that's also a duplicate in a box
+	input.Set(0, 8, 3)
+	input.Set(2, 6, 3)
+
+	_, err := solver.New(input, solver.WithDiagonals())
+	require.IsType(t, &solver.InvalidBoardError{}, err)
+	require.ElementsMatch(t, []*solver.InvalidSquareError{{
+		Row: 1,
+		Col: 1,
+		Msg: `duplicate number in diagonal`,
+	}, {
+		Row: 7,
+		Col: 7,
+		Msg: `duplicate number in diagonal`,
+	}, {
+		Row: 0,
+		Col: 8,
+		Msg: `duplicate number in row, column, or box`,
+	}, {
+		Row: 2,
+		Col: 6,
+		Msg: `duplicate number in row, column, or box`,
+	}}, err.(*solver.InvalidBoardError).InvalidSquares)
+}
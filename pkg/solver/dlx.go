@@ -0,0 +1,245 @@
+package solver
+
+import "errors"
+
+// ErrUnsupportedConstraints is returned by NewDLX for boards with extra constraints or cages, which
+// don't fit into its exact cover model.
+var ErrUnsupportedConstraints = errors.New(`dancing links only supports the classic rules and diagonals`)
+
+// DLX solves boards by modelling them as an exact cover problem and searching it with Knuth's
+// Dancing Links (Algorithm X). Each row of the cover matrix places one number in one square, and
+// the columns are the constraints a solution has to satisfy exactly once: every square is filled,
+// and every number appears in every row, column, and box, and on each diagonal if those are enabled.
+type DLX struct {
+	geo       *geometry
+	nums      []int
+	diagonals bool
+
+	nodes []dlxNode
+	// sizes holds the number of rows left in each column, indexed by the column's header node.
+	sizes []int
+	// choices holds the square and number placed by each row of the matrix.
+	choices []guess
+	// stack holds the matrix rows chosen so far in the search, as the index of one of their nodes.
+	stack []int
+}
+
+// dlxNode is a node in the cover matrix. Nodes link to their neighbors by index into DLX.nodes.
+// Node 0 is the root, and the column headers follow it.
+type dlxNode struct {
+	left, right int
+	up, down    int
+	col         int
+	row         int
+}
+
+const (
+	squareConstraint = iota
+	rowConstraint
+	colConstraint
+	boxConstraint
+	numConstraintKinds
+)
+
+// NewDLX validates the board the same way New does and builds its cover matrix. Constraints that
+// are already satisfied by the board's numbers are left out of the matrix. Of the options, only the
+// box size, regions, and diagonals have any effect.
+func NewDLX(board Board, opts ...Option) (*DLX, error) {
+	s, err := New(board, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.opts.constraints) != 0 || len(s.opts.cages) != 0 {
+		return nil, ErrUnsupportedConstraints
+	}
+	d := &DLX{
+		geo:       s.geo,
+		nums:      s.nums,
+		diagonals: s.opts.diagonals,
+	}
+
+	numConstraints := numConstraintKinds * len(s.nums)
+	if d.diagonals {
+		// the diagonal constraints come after every other kind
+		numConstraints += 2 * d.geo.dim
+	}
+	satisfied := make([]bool, numConstraints)
+	for idx, n := range s.nums {
+		if n != Empty {
+			for _, con := range d.constraintsFor(idx, n) {
+				satisfied[con] = true
+			}
+		}
+	}
+
+	// map every constraint that still has to be satisfied to a column header
+	colFor := make([]int, len(satisfied))
+	d.nodes = append(d.nodes, dlxNode{})
+	for con, ok := range satisfied {
+		if !ok {
+			colFor[con] = d.addColumn()
+		}
+	}
+	d.sizes = make([]int, len(d.nodes))
+
+	for idx, n := range s.nums {
+		if n != Empty {
+			continue
+		}
+		cands := s.cache.candidates(idx/d.geo.dim, idx%d.geo.dim)
+		for n := MinEntry; n <= d.geo.dim; n++ {
+			if !cands.has(n) {
+				continue
+			}
+			cons := d.constraintsFor(idx, n)
+			for i, con := range cons {
+				cons[i] = colFor[con]
+			}
+			d.addRow(guess{idx: idx, n: n}, cons)
+		}
+	}
+	return d, nil
+}
+
+// constraintsFor returns the constraints satisfied by writing n at the square.
+func (d *DLX) constraintsFor(idx, n int) []int {
+	g := d.geo
+	pt := g.points[idx]
+	area := g.dim * g.dim
+	cons := []int{
+		squareConstraint*area + idx,
+		rowConstraint*area + pt.row*g.dim + n - 1,
+		colConstraint*area + pt.col*g.dim + n - 1,
+		boxConstraint*area + pt.box*g.dim + n - 1,
+	}
+	if d.diagonals {
+		if pt.row == pt.col {
+			cons = append(cons, numConstraintKinds*area+n-1)
+		}
+		if pt.row+pt.col == g.dim-1 {
+			cons = append(cons, numConstraintKinds*area+g.dim+n-1)
+		}
+	}
+	return cons
+}
+
+func (d *DLX) addColumn() int {
+	col := len(d.nodes)
+	d.nodes = append(d.nodes, dlxNode{
+		left:  d.nodes[0].left,
+		right: 0,
+		up:    col,
+		down:  col,
+		col:   col,
+	})
+	d.nodes[d.nodes[col].left].right = col
+	d.nodes[0].left = col
+	return col
+}
+
+func (d *DLX) addRow(choice guess, cols []int) {
+	row := len(d.choices)
+	d.choices = append(d.choices, choice)
+	first := len(d.nodes)
+	for i, col := range cols {
+		node := len(d.nodes)
+		d.nodes = append(d.nodes, dlxNode{
+			left:  first + (i+len(cols)-1)%len(cols),
+			right: first + (i+1)%len(cols),
+			up:    d.nodes[col].up,
+			down:  col,
+			col:   col,
+			row:   row,
+		})
+		d.nodes[d.nodes[node].up].down = node
+		d.nodes[col].up = node
+		d.sizes[col]++
+	}
+}
+
+func (d *DLX) Solve() (Board, error) {
+	solution := Board{dim: d.geo.dim}
+	found := d.search(func() bool {
+		for idx, n := range d.nums {
+			solution.cells[idx] = uint8(n)
+		}
+		for _, node := range d.stack {
+			choice := d.choices[d.nodes[node].row]
+			solution.cells[choice.idx] = uint8(choice.n)
+		}
+		return true
+	})
+	if !found {
+		return Board{}, ErrNoSolution
+	}
+	return solution, nil
+}
+
+// CountSolutions counts the solutions of the board, stopping once limit solutions have been found.
+// A limit of zero or less counts every solution.
+func (d *DLX) CountSolutions(limit int) int {
+	count := 0
+	d.search(func() bool {
+		count++
+		return limit > 0 && count >= limit
+	})
+	return count
+}
+
+// search runs Algorithm X, calling found for every exact cover. If found returns true the search
+// stops and search returns true. The matrix is restored either way.
+func (d *DLX) search(found func() bool) bool {
+	if d.nodes[0].right == 0 {
+		return found()
+	}
+
+	// branch on the column with the fewest rows to keep the search tree narrow
+	col := d.nodes[0].right
+	for c := d.nodes[col].right; c != 0; c = d.nodes[c].right {
+		if d.sizes[c] < d.sizes[col] {
+			col = c
+		}
+	}
+
+	d.cover(col)
+	defer d.uncover(col)
+	for row := d.nodes[col].down; row != col; row = d.nodes[row].down {
+		d.stack = append(d.stack, row)
+		for n := d.nodes[row].right; n != row; n = d.nodes[n].right {
+			d.cover(d.nodes[n].col)
+		}
+		stop := d.search(found)
+		for n := d.nodes[row].left; n != row; n = d.nodes[n].left {
+			d.uncover(d.nodes[n].col)
+		}
+		d.stack = d.stack[:len(d.stack)-1]
+		if stop {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DLX) cover(col int) {
+	d.nodes[d.nodes[col].right].left = d.nodes[col].left
+	d.nodes[d.nodes[col].left].right = d.nodes[col].right
+	for row := d.nodes[col].down; row != col; row = d.nodes[row].down {
+		for n := d.nodes[row].right; n != row; n = d.nodes[n].right {
+			d.nodes[d.nodes[n].down].up = d.nodes[n].up
+			d.nodes[d.nodes[n].up].down = d.nodes[n].down
+			d.sizes[d.nodes[n].col]--
+		}
+	}
+}
+
+func (d *DLX) uncover(col int) {
+	for row := d.nodes[col].up; row != col; row = d.nodes[row].up {
+		for n := d.nodes[row].left; n != row; n = d.nodes[n].left {
+			d.sizes[d.nodes[n].col]++
+			d.nodes[d.nodes[n].down].up = n
+			d.nodes[d.nodes[n].up].down = n
+		}
+	}
+	d.nodes[d.nodes[col].right].left = col
+	d.nodes[d.nodes[col].left].right = col
+}
@@ -0,0 +1,82 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestDLXSolve(t *testing.T) {
+	input := [][]int{
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 3, 0, 8, 5},
+		{0, 0, 1, 0, 2, 0, 0, 0, 0},
+		{0, 0, 0, 5, 0, 7, 0, 0, 0},
+		{0, 0, 4, 0, 0, 0, 1, 0, 0},
+		{0, 9, 0, 0, 0, 0, 0, 0, 0},
+		{5, 0, 0, 0, 0, 0, 0, 7, 3},
+		{0, 0, 2, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 4, 0, 0, 0, 9},
+	}
+	d, err := solver.NewDLX(mustBoard(t, input))
+	require.NoError(t, err)
+	actual, err := d.Solve()
+	require.NoError(t, err)
+
+	s, err := newSolver(input, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	expected, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// solving doesn't use up the matrix
+	again, err := d.Solve()
+	require.NoError(t, err)
+	require.Equal(t, expected, again)
+}
+
+func TestDLXNoSolution(t *testing.T) {
+	input := [][]int{
+		{5, 1, 6, 8, 4, 9, 7, 3, 2},
+		{3, 0, 7, 6, 0, 5, 0, 0, 0},
+		{8, 0, 9, 7, 0, 0, 0, 6, 5},
+		{1, 3, 5, 0, 6, 0, 9, 0, 7},
+		{4, 7, 2, 5, 9, 1, 0, 0, 6},
+		{9, 6, 8, 3, 7, 0, 0, 5, 0},
+		{2, 5, 3, 1, 8, 6, 0, 7, 4},
+		{6, 8, 4, 2, 0, 7, 5, 0, 0},
+		{7, 9, 1, 0, 5, 0, 6, 0, 8},
+	}
+	d, err := solver.NewDLX(mustBoard(t, input))
+	require.NoError(t, err)
+
+	actual, err := d.Solve()
+	require.Equal(t, solver.ErrNoSolution, err)
+	require.Equal(t, solver.Board{}, actual)
+	require.Zero(t, d.CountSolutions(0))
+}
+
+func TestDLXCountSolutions(t *testing.T) {
+	d, err := solver.NewDLX(mustBoard(t, multiSolutionBoard()))
+	require.NoError(t, err)
+	require.Equal(t, 4, d.CountSolutions(0))
+	require.Equal(t, 2, d.CountSolutions(2))
+
+	d, err = solver.NewDLX(solver.NewEmptyBoard())
+	require.NoError(t, err)
+	require.Equal(t, 100, d.CountSolutions(100))
+}
+
+func TestNewDLXValidates(t *testing.T) {
+	_, err := solver.NewDLX(mustBoard(t, [][]int{{1, 2, 3, 4}, {3, 4, 1, 2}, {2, 1, 4, 3}, {4, 3, 2, 1}}))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+
+	input := solver.NewEmptyBoard()
+	input.Set(0, 0, 1)
+	input.Set(0, 1, 1)
+	_, err = solver.NewDLX(input)
+	require.IsType(t, &solver.InvalidBoardError{}, err)
+	require.Len(t, err.(*solver.InvalidBoardError).InvalidSquares, 2)
+}
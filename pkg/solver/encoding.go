@@ -0,0 +1,260 @@
+package solver
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// binaryVersion is the version of the binary encoding written by MarshalBinary.
+const binaryVersion = 1
+
+// The kinds of binary encoding, recorded after the version.
+const (
+	// puzzleEncoding holds a mask of the filled squares followed by the number in each of them.
+	puzzleEncoding = 1
+	// gridEncoding holds a complete grid as the choice made at each square among the numbers left
+	// open by the squares before it.
+	gridEncoding = 2
+)
+
+// MarshalBinary encodes the board compactly. The encoding starts with a version byte, a byte for
+// the kind of encoding and a byte for the board's size.
+//
+// A complete grid whose rows and columns hold no number twice, and whose boxes don't either when
+// its size is a square, is encoded as the choice made at each square among the numbers its row,
+// column and box leave open, so squares with only one choice take no space. A 9x9 grid takes about
+// 12 bytes this way. Any other board is encoded as a mask of its filled squares followed by their
+// numbers, each in as few bits as the board's size allows.
+func (b Board) MarshalBinary() ([]byte, error) {
+	header := []byte{binaryVersion, puzzleEncoding, byte(b.dim)}
+	if grid, ok := b.gridNumber(); ok {
+		header[1] = gridEncoding
+		return append(header, grid.Bytes()...), nil
+	}
+	return append(header, b.puzzleBits()...), nil
+}
+
+// UnmarshalBinary decodes a board encoded by MarshalBinary. It fails with ErrInvalidEncoding if
+// the data isn't a board or was written by an unknown version of the encoding.
+func (b *Board) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return fmt.Errorf(`%w: expected at least 3 bytes, found %d`, ErrInvalidEncoding, len(data))
+	}
+	version, kind, dim, payload := data[0], data[1], int(data[2]), data[3:]
+	if version != binaryVersion {
+		return fmt.Errorf(`%w: unknown version %d`, ErrInvalidEncoding, version)
+	}
+	if dim > MaxDimension {
+		return fmt.Errorf(`%w: size %d is larger than %d`, ErrInvalidEncoding, dim, MaxDimension)
+	}
+	res := Board{dim: dim}
+	var ok bool
+	switch kind {
+	case puzzleEncoding:
+		ok = res.readPuzzleBits(payload)
+	case gridEncoding:
+		// leading zeros would give the same grid more than one encoding
+		ok = (len(payload) == 0 || payload[0] != 0) && res.readGridNumber(new(big.Int).SetBytes(payload))
+	default:
+		return fmt.Errorf(`%w: unknown kind %d`, ErrInvalidEncoding, kind)
+	}
+	if !ok {
+		return fmt.Errorf(`%w: malformed board`, ErrInvalidEncoding)
+	}
+	*b = res
+	return nil
+}
+
+// puzzleBits returns the mask of the board's filled squares followed by their numbers, less one.
+func (b *Board) puzzleBits() []byte {
+	var bw bitWriter
+	cells := b.cells[:b.dim*b.dim]
+	for _, n := range cells {
+		if n != Empty {
+			bw.write(1, 1)
+		} else {
+			bw.write(0, 1)
+		}
+	}
+	width := digitWidth(b.dim)
+	for _, n := range cells {
+		if n != Empty {
+			bw.write(uint(n-1), width)
+		}
+	}
+	return bw.buf
+}
+
+// readPuzzleBits fills in the board from the bits written by puzzleBits, returning false unless
+// they describe a board of the board's size exactly.
+func (b *Board) readPuzzleBits(data []byte) bool {
+	br := bitReader{buf: data}
+	cells := b.cells[:b.dim*b.dim]
+	for i := range cells {
+		filled, ok := br.read(1)
+		if !ok {
+			return false
+		}
+		// mark the filled squares until their numbers are read
+		cells[i] = uint8(filled)
+	}
+	width := digitWidth(b.dim)
+	for i, filled := range cells {
+		if filled == 0 {
+			continue
+		}
+		n, ok := br.read(width)
+		if !ok || int(n) >= b.dim {
+			return false
+		}
+		cells[i] = uint8(n + 1)
+	}
+	return br.done()
+}
+
+// gridNumber returns the choices made at each square of a complete grid as a single number, with
+// the first square's choice as its least significant digit. It returns false if the board isn't a
+// complete grid that follows the rules.
+func (b *Board) gridNumber() (*big.Int, bool) {
+	if b.dim == 0 {
+		return nil, false
+	}
+	choices := make([]int, 0, b.dim*b.dim)
+	radixes := make([]int, 0, b.dim*b.dim)
+	ok := b.walkGrid(func(idx int, open digitSet) int {
+		n := int(b.cells[idx])
+		if !open.has(n) {
+			return Empty
+		}
+		choices = append(choices, (open & (digitBit(n) - 1)).len())
+		radixes = append(radixes, open.len())
+		return n
+	})
+	if !ok {
+		return nil, false
+	}
+	res := new(big.Int)
+	radix := new(big.Int)
+	for i := len(choices) - 1; i >= 0; i-- {
+		if radixes[i] == 1 {
+			continue
+		}
+		res.Mul(res, radix.SetInt64(int64(radixes[i])))
+		res.Add(res, radix.SetInt64(int64(choices[i])))
+	}
+	return res, true
+}
+
+// readGridNumber fills in the board from the number written by gridNumber, returning false unless
+// the number describes a grid of the board's size exactly.
+func (b *Board) readGridNumber(grid *big.Int) bool {
+	if b.dim == 0 {
+		return false
+	}
+	radix := new(big.Int)
+	choice := new(big.Int)
+	ok := b.walkGrid(func(idx int, open digitSet) int {
+		if open == 0 {
+			return Empty
+		}
+		grid.DivMod(grid, radix.SetInt64(int64(open.len())), choice)
+		n := open.digits()[choice.Int64()]
+		b.cells[idx] = uint8(n)
+		return n
+	})
+	return ok && grid.Sign() == 0
+}
+
+// walkGrid visits the squares of the board in order, passing each one the numbers that its row,
+// column and box leave open. Only boards whose size is a square have boxes. Each visit returns the
+// number in its square, or Empty to stop the walk, in which case walkGrid returns false.
+func (b *Board) walkGrid(visit func(idx int, open digitSet) int) bool {
+	all := digitSet(0)
+	for n := 1; n <= b.dim; n++ {
+		all |= digitBit(n)
+	}
+	boxSize := 0
+	for s := 1; s*s <= b.dim; s++ {
+		if s*s == b.dim {
+			boxSize = s
+		}
+	}
+	var rows, cols, boxes [MaxDimension]digitSet
+	for r := 0; r < b.dim; r++ {
+		for c := 0; c < b.dim; c++ {
+			box := 0
+			if boxSize > 0 {
+				box = (r/boxSize)*boxSize + c/boxSize
+			}
+			open := all &^ (rows[r] | cols[c])
+			if boxSize > 0 {
+				open &^= boxes[box]
+			}
+			n := visit(r*b.dim+c, open)
+			if n == Empty {
+				return false
+			}
+			rows[r] |= digitBit(n)
+			cols[c] |= digitBit(n)
+			boxes[box] |= digitBit(n)
+		}
+	}
+	return true
+}
+
+// digitWidth returns the number of bits needed to hold a number of a board of size dim, less one.
+func digitWidth(dim int) int {
+	if dim <= 1 {
+		return 0
+	}
+	return bits.Len(uint(dim - 1))
+}
+
+// bitWriter appends bits to a byte slice, most significant bit first.
+type bitWriter struct {
+	buf []byte
+	n   int
+}
+
+// write appends the lowest width bits of v.
+func (bw *bitWriter) write(v uint, width int) {
+	for i := width - 1; i >= 0; i-- {
+		if bw.n%8 == 0 {
+			bw.buf = append(bw.buf, 0)
+		}
+		if v>>uint(i)&1 == 1 {
+			bw.buf[len(bw.buf)-1] |= 0x80 >> uint(bw.n%8)
+		}
+		bw.n++
+	}
+}
+
+// bitReader reads the bits written by a bitWriter.
+type bitReader struct {
+	buf []byte
+	n   int
+}
+
+// read returns the next width bits, or false if there aren't that many left.
+func (br *bitReader) read(width int) (uint, bool) {
+	if br.n+width > len(br.buf)*8 {
+		return 0, false
+	}
+	var v uint
+	for i := 0; i < width; i++ {
+		bit := br.buf[br.n/8] >> uint(7-br.n%8) & 1
+		v = v<<1 | uint(bit)
+		br.n++
+	}
+	return v, true
+}
+
+// done reports whether every byte has been read and the padding at the end is all zeros.
+func (br *bitReader) done() bool {
+	if (br.n+7)/8 != len(br.buf) {
+		return false
+	}
+	pad := uint(len(br.buf)*8 - br.n)
+	return pad == 0 || br.buf[len(br.buf)-1]&(1<<pad-1) == 0
+}
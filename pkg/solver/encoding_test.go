@@ -0,0 +1,179 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	solved := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	// a full board that repeats numbers can't use the grid encoding
+	repeated := mustBoard(t, solved).Rows()
+	repeated[0][0] = 1
+	tests := []struct {
+		desc    string
+		board   solver.Board
+		expKind byte
+		expLen  int
+	}{{
+		desc:    `puzzle`,
+		board:   mustBoard(t, multiSolutionBoard()),
+		expKind: 1,
+		// 81 bits of mask and 4 bits for each of 40 clues
+		expLen: 3 + 31,
+	}, {
+		desc:    `empty board`,
+		board:   solver.NewEmptyBoard(),
+		expKind: 1,
+		expLen:  3 + 11,
+	}, {
+		desc:    `zero board`,
+		board:   solver.Board{},
+		expKind: 1,
+		expLen:  3,
+	}, {
+		desc:    `complete grid`,
+		board:   mustBoard(t, solved),
+		expKind: 2,
+		expLen:  3 + 11,
+	}, {
+		desc:    `complete board with repeats`,
+		board:   mustBoard(t, repeated),
+		expKind: 1,
+		expLen:  3 + 51,
+	}, {
+		desc:    `6x6 grid without square boxes`,
+		board:   patternGrid(t, 2, 3),
+		expKind: 2,
+		expLen:  3 + 5,
+	}, {
+		desc:    `25x25 grid`,
+		board:   patternGrid(t, 5, 5),
+		expKind: 2,
+		expLen:  3 + 160,
+	}, {
+		desc:    `1x1 grid`,
+		board:   mustBoard(t, [][]int{{1}}),
+		expKind: 2,
+		expLen:  3,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			data, err := tc.board.MarshalBinary()
+			require.NoError(t, err)
+			require.Equal(t, byte(1), data[0])
+			require.Equal(t, tc.expKind, data[1])
+			require.Equal(t, byte(tc.board.Size()), data[2])
+			require.Len(t, data, tc.expLen)
+
+			var decoded solver.Board
+			require.NoError(t, decoded.UnmarshalBinary(data))
+			require.Equal(t, tc.board, decoded)
+		})
+	}
+}
+
+func TestBinaryGridSize(t *testing.T) {
+	// complete grids take well under the 41 bytes of 4 bits per square
+	s, err := newSolver(solver.NewEmptyBoard().Rows(), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	sols := s.Solutions()
+	defer sols.Stop()
+	for i := 0; i < 100; i++ {
+		grid, ok := sols.Next()
+		require.True(t, ok)
+		data, err := grid.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, byte(2), data[1])
+		require.LessOrEqual(t, len(data), 3+13)
+
+		var decoded solver.Board
+		require.NoError(t, decoded.UnmarshalBinary(data))
+		require.Equal(t, grid, decoded)
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	puzzle, err := mustBoard(t, multiSolutionBoard()).MarshalBinary()
+	require.NoError(t, err)
+	grid, err := patternGrid(t, 3, 3).MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(2), grid[1])
+
+	tests := []struct {
+		desc string
+		data []byte
+	}{{
+		desc: `empty`,
+		data: nil,
+	}, {
+		desc: `unknown version`,
+		data: []byte{2, 1, 0},
+	}, {
+		desc: `unknown kind`,
+		data: []byte{1, 3, 0},
+	}, {
+		desc: `too large`,
+		data: []byte{1, 1, 26},
+	}, {
+		desc: `truncated puzzle`,
+		data: puzzle[:len(puzzle)-1],
+	}, {
+		desc: `extra bytes`,
+		data: append(append([]byte(nil), puzzle...), 0),
+	}, {
+		desc: `padding bits set`,
+		data: []byte{1, 1, 2, 0x01},
+	}, {
+		desc: `number out of range`,
+		// a 3x3 board with its first square filled in with 4
+		data: []byte{1, 1, 3, 0x80, 0x60},
+	}, {
+		desc: `grid number too large`,
+		data: []byte{1, 2, 4, 0xff, 0xff, 0xff, 0xff},
+	}, {
+		desc: `grid with leading zeros`,
+		data: append([]byte{1, 2, 9, 0}, grid[3:]...),
+	}, {
+		desc: `zero-size grid`,
+		data: []byte{1, 2, 0},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			b := mustBoard(t, multiSolutionBoard())
+			err := b.UnmarshalBinary(tc.data)
+			require.ErrorIs(t, err, solver.ErrInvalidEncoding)
+			// the board is left as it was
+			require.Equal(t, mustBoard(t, multiSolutionBoard()), b)
+		})
+	}
+}
+
+// patternGrid returns a complete grid for boxes of the given size, made by shifting each row of
+// the grid along from the one above it.
+func patternGrid(t *testing.T, boxRows, boxCols int) solver.Board {
+	dim := boxRows * boxCols
+	rows := make([][]int, dim)
+	for r := range rows {
+		rows[r] = make([]int, dim)
+		for c := range rows[r] {
+			rows[r][c] = (boxCols*(r%boxRows)+r/boxRows+c)%dim + 1
+		}
+	}
+	return mustBoard(t, rows)
+}
@@ -0,0 +1,114 @@
+package solver
+
+import "math/bits"
+
+// fish finds k rows in which a number's candidates all lie in the same k columns. The number has
+// to go in those columns in those rows, so it's removed from the rest of the columns. The same
+// goes with rows and columns swapped.
+func (ls *logicSolver) fish(t Technique, k int) (Step, bool) {
+	dim := ls.s.geo.dim
+	for n := MinEntry; n <= dim; n++ {
+		for _, byRow := range []bool{true, false} {
+			at := func(base, cover int) int {
+				if byRow {
+					return base*dim + cover
+				}
+				return cover*dim + base
+			}
+			// covers holds the lines crossing each base line where it has the number as a candidate
+			covers := make([]uint32, dim)
+			var pool []int
+			for base := 0; base < dim; base++ {
+				for cover := 0; cover < dim; cover++ {
+					if ls.cands[at(base, cover)].has(n) {
+						covers[base] |= 1 << uint(cover)
+					}
+				}
+				if l := bits.OnesCount32(covers[base]); l >= 2 && l <= k {
+					pool = append(pool, base)
+				}
+			}
+			var step Step
+			found := eachCombination(len(pool), k, func(pick []int) bool {
+				var span uint32
+				bases := make([]int, k)
+				for i, p := range pick {
+					bases[i] = pool[p]
+					span |= covers[pool[p]]
+				}
+				if bits.OnesCount32(span) != k {
+					return false
+				}
+				var cells []int
+				var elims []Candidate
+				for base := 0; base < dim; base++ {
+					isBase := containsInt(bases, base)
+					for cover := 0; cover < dim; cover++ {
+						idx := at(base, cover)
+						if span&(1<<uint(cover)) == 0 || !ls.cands[idx].has(n) {
+							continue
+						}
+						if isBase {
+							cells = append(cells, idx)
+						} else {
+							elims = append(elims, ls.candidate(idx, n))
+						}
+					}
+				}
+				if len(elims) == 0 {
+					return false
+				}
+				step = Step{
+					Technique:    t,
+					Cells:        ls.cellsOf(cells),
+					Eliminations: elims,
+				}
+				return true
+			})
+			if found {
+				return step, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// xyWing finds a pivot square with candidates a and b that sees a pincer with candidates a and c
+// and another with b and c. Whichever number the pivot takes, one of the pincers has to be c, so c
+// is removed from every square that sees both pincers.
+func (ls *logicSolver) xyWing() (Step, bool) {
+	for pivot, pc := range ls.cands {
+		if pc.len() != 2 {
+			continue
+		}
+		for first, fc := range ls.cands {
+			if fc.len() != 2 || (fc&pc).len() != 1 || !ls.sees(pivot, first) {
+				continue
+			}
+			c := fc &^ pc
+			want := (pc &^ fc) | c
+			for second, sc := range ls.cands {
+				if sc != want || second == first || !ls.sees(pivot, second) {
+					continue
+				}
+				var elims []Candidate
+				for idx, cands := range ls.cands {
+					if idx == first || idx == second || cands&c == 0 {
+						continue
+					}
+					if ls.sees(idx, first) && ls.sees(idx, second) {
+						elims = append(elims, ls.candidate(idx, c.digits()[0]))
+					}
+				}
+				if len(elims) != 0 {
+					return Step{
+						Technique:    XYWing,
+						Cells:        ls.cellsOf([]int{pivot, first, second}),
+						Eliminations: elims,
+					}, true
+				}
+			}
+		}
+	}
+	return Step{}, false
+}
@@ -0,0 +1,139 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+)
+
+// Tier is a named difficulty level for publishing puzzles.
+type Tier int
+
+const (
+	// Easy puzzles need only singles.
+	Easy Tier = iota + 1
+	// Medium puzzles need pointing pairs or box/line reductions.
+	Medium
+	// Hard puzzles need naked or hidden pairs or triples.
+	Hard
+	// Expert puzzles need X-Wings, Swordfish, or XY-Wings.
+	Expert
+)
+
+var tierNames = map[Tier]string{
+	Easy:   `easy`,
+	Medium: `medium`,
+	Hard:   `hard`,
+	Expert: `expert`,
+}
+
+func (t Tier) String() string {
+	if name, ok := tierNames[t]; ok {
+		return name
+	}
+	return `unknown`
+}
+
+// MarshalText encodes the tier as its name. The zero Tier, which only an empty Rating has, is
+// encoded as an empty name.
+func (t Tier) MarshalText() ([]byte, error) {
+	if t == 0 {
+		return []byte{}, nil
+	}
+	if _, ok := tierNames[t]; !ok {
+		return nil, fmt.Errorf(`unknown tier %d`, int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a tier from its name, or the zero Tier from an empty name.
+func (t *Tier) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = 0
+		return nil
+	}
+	for tier, name := range tierNames {
+		if name == string(text) {
+			*t = tier
+			return nil
+		}
+	}
+	return fmt.Errorf(`unknown tier %q`, text)
+}
+
+// techniqueWeights holds how much each step using a technique adds to a puzzle's score.
+var techniqueWeights = map[Technique]int{
+	NakedSingle:      1,
+	HiddenSingle:     2,
+	PointingPair:     5,
+	BoxLineReduction: 5,
+	NakedPair:        8,
+	HiddenPair:       10,
+	NakedTriple:      12,
+	HiddenTriple:     15,
+	XWing:            20,
+	Swordfish:        30,
+	XYWing:           30,
+}
+
+// hardestWeight scales the weight of a puzzle's hardest technique in its score.
+const hardestWeight = 100
+
+// tierOf returns the tier of a puzzle whose hardest step uses the technique.
+func tierOf(t Technique) Tier {
+	switch {
+	case t >= XWing:
+		return Expert
+	case t >= NakedPair:
+		return Hard
+	case t >= PointingPair:
+		return Medium
+	default:
+		return Easy
+	}
+}
+
+// Rating describes how hard a puzzle is to solve by hand.
+type Rating struct {
+	// Tier is set by the hardest technique the puzzle needs.
+	Tier Tier `json:"tier"`
+	// Score is mostly set by the hardest technique the puzzle needs, with the weight of every step
+	// added on top, so that puzzles needing more or harder steps score higher within a tier.
+	Score int `json:"score"`
+	// Hardest is the hardest technique the puzzle needs.
+	Hardest Technique `json:"hardest"`
+	// Steps is the number of steps in the solution.
+	Steps int `json:"steps"`
+}
+
+// Rate grades the board by solving it logically. It fails with ErrNoSolution or
+// ErrMultipleSolutions unless the board has exactly one solution, and with ErrNeedsGuessing if the
+// known techniques can't solve it.
+func Rate(board Board, opts ...Option) (Rating, error) {
+	return RateContext(context.Background(), board, opts...)
+}
+
+// RateContext is like Rate, but gives up with ErrCanceled once ctx is done while checking that the
+// board has a unique solution.
+func RateContext(ctx context.Context, board Board, opts ...Option) (Rating, error) {
+	s, err := newUniqueSolver(ctx, board, ErrNoSolution, opts)
+	if err != nil {
+		return Rating{}, err
+	}
+
+	steps, err := s.SolveLogically()
+	if err != nil {
+		return Rating{}, err
+	}
+	r := Rating{
+		Steps: len(steps),
+	}
+	for _, step := range steps {
+		r.Score += techniqueWeights[step.Technique]
+		if step.Technique > r.Hardest {
+			r.Hardest = step.Technique
+		}
+	}
+	r.Score += hardestWeight * techniqueWeights[r.Hardest]
+	r.Tier = tierOf(r.Hardest)
+	return r, nil
+}
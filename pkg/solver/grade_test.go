@@ -0,0 +1,130 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		desc       string
+		board      [][]int
+		expTier    solver.Tier
+		expHardest solver.Technique
+	}{{
+		desc: `easy`,
+		board: [][]int{
+			{0, 0, 3, 0, 2, 0, 6, 0, 0},
+			{9, 0, 0, 3, 0, 5, 0, 0, 1},
+			{0, 0, 1, 8, 0, 6, 4, 0, 0},
+			{0, 0, 8, 1, 0, 2, 9, 0, 0},
+			{7, 0, 0, 0, 0, 0, 0, 0, 8},
+			{0, 0, 6, 7, 0, 8, 2, 0, 0},
+			{0, 0, 2, 6, 0, 9, 5, 0, 0},
+			{8, 0, 0, 2, 0, 3, 0, 0, 9},
+			{0, 0, 5, 0, 1, 0, 3, 0, 0},
+		},
+		expTier:    solver.Easy,
+		expHardest: solver.NakedSingle,
+	}, {
+		desc:       `medium`,
+		board:      hardBoard(),
+		expTier:    solver.Medium,
+		expHardest: solver.PointingPair,
+	}, {
+		desc: `hard`,
+		board: [][]int{
+			{0, 0, 0, 0, 0, 0, 5, 2, 0},
+			{0, 8, 0, 4, 0, 0, 0, 0, 0},
+			{0, 3, 0, 0, 0, 9, 0, 0, 0},
+			{5, 0, 1, 0, 0, 0, 6, 0, 0},
+			{2, 0, 0, 7, 0, 0, 0, 0, 0},
+			{0, 0, 0, 3, 0, 0, 0, 0, 0},
+			{6, 0, 0, 0, 1, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 7, 0, 4},
+			{0, 0, 0, 0, 0, 0, 0, 3, 0},
+		},
+		expTier:    solver.Hard,
+		expHardest: solver.NakedPair,
+	}, {
+		desc: `expert`,
+		board: [][]int{
+			{0, 4, 3, 0, 8, 0, 2, 5, 0},
+			{6, 0, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 1, 0, 9, 4},
+			{9, 0, 0, 0, 0, 4, 0, 7, 0},
+			{0, 0, 0, 6, 0, 8, 0, 0, 0},
+			{0, 1, 0, 2, 0, 0, 0, 0, 3},
+			{8, 2, 0, 5, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0, 0, 5},
+			{0, 3, 4, 0, 9, 0, 7, 1, 0},
+		},
+		expTier:    solver.Expert,
+		expHardest: solver.XWing,
+	}}
+	prevScore := 0
+	for _, tc := range tests {
+		r, err := solver.Rate(mustBoard(t, tc.board))
+		require.NoError(t, err, tc.desc)
+		require.Equal(t, tc.expTier, r.Tier, tc.desc)
+		require.Equal(t, tc.expHardest, r.Hardest, tc.desc)
+		require.NotZero(t, r.Steps, tc.desc)
+		// each puzzle is harder than the last
+		require.Greater(t, r.Score, prevScore, tc.desc)
+		prevScore = r.Score
+	}
+}
+
+func TestRateErrors(t *testing.T) {
+	needsGuessing := [][]int{
+		{4, 8, 0, 3, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 7, 1},
+		{0, 2, 0, 0, 0, 0, 0, 0, 0},
+		{7, 0, 5, 0, 0, 0, 0, 6, 0},
+		{0, 0, 0, 2, 0, 0, 8, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 7, 6, 0, 0, 0},
+		{3, 0, 0, 0, 0, 0, 4, 0, 0},
+		{0, 0, 0, 0, 5, 0, 0, 0, 0},
+	}
+	noSolution := multiSolutionBoard()
+	noSolution[0][0], noSolution[0][1] = 5, 3
+
+	tests := []struct {
+		desc   string
+		board  [][]int
+		expErr error
+	}{{
+		desc:   `multiple solutions`,
+		board:  multiSolutionBoard(),
+		expErr: solver.ErrMultipleSolutions,
+	}, {
+		desc:   `no solution`,
+		board:  noSolution,
+		expErr: solver.ErrNoSolution,
+	}, {
+		desc:   `needs guessing`,
+		board:  needsGuessing,
+		expErr: solver.ErrNeedsGuessing,
+	}, {
+		desc:   `wrong size`,
+		board:  solver.NewEmptyBoard(solver.WithBoxSize(2, 2)).Rows(),
+		expErr: solver.ErrWrongNumberOfRows,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := solver.Rate(mustBoard(t, tc.board))
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
+
+func TestTierString(t *testing.T) {
+	require.Equal(t, `easy`, solver.Easy.String())
+	require.Equal(t, `expert`, solver.Expert.String())
+	require.Equal(t, `unknown`, solver.Tier(0).String())
+}
@@ -0,0 +1,31 @@
+package solver
+
+import "context"
+
+// Hint returns the easiest logical step available from the board, for a player who's stuck. The
+// board may hold the player's own numbers alongside the clues. Hint fails with
+// ErrInconsistentBoard if those numbers can't lead to a solution, with ErrMultipleSolutions if the
+// board doesn't have a unique solution, with ErrAlreadySolved if it's full, and with
+// ErrNeedsGuessing if no known technique makes progress.
+func Hint(board Board, opts ...Option) (Step, error) {
+	return HintContext(context.Background(), board, opts...)
+}
+
+// HintContext is like Hint, but gives up with ErrCanceled once ctx is done while checking that the
+// board has a unique solution.
+func HintContext(ctx context.Context, board Board, opts ...Option) (Step, error) {
+	s, err := newUniqueSolver(ctx, board, ErrInconsistentBoard, opts)
+	if err != nil {
+		return Step{}, err
+	}
+
+	ls := newLogicSolver(s)
+	if ls.solved() {
+		return Step{}, ErrAlreadySolved
+	}
+	step, ok := ls.next()
+	if !ok {
+		return Step{}, ErrNeedsGuessing
+	}
+	return step, nil
+}
@@ -0,0 +1,102 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestHint(t *testing.T) {
+	input := hardBoard()
+	s, err := newSolver(input)
+	require.NoError(t, err)
+	steps, err := s.SolveLogically()
+	require.NoError(t, err)
+
+	// following the hints retraces the logical solution until it eliminates candidates, which
+	// can't be written on the board
+	board := mustBoard(t, input)
+	for _, step := range steps {
+		hint, err := solver.Hint(board)
+		require.NoError(t, err)
+		require.Equal(t, step, hint)
+		if len(hint.Placements) == 0 {
+			break
+		}
+		for _, p := range hint.Placements {
+			board.Set(p.Row, p.Col, p.Digit)
+		}
+	}
+}
+
+func TestHintErrors(t *testing.T) {
+	solved := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	// the 5 in the top left corner breaks no rules, but the solution has a 4 there
+	inconsistent := [][]int{
+		{5, 0, 3, 0, 2, 0, 6, 0, 0},
+		{9, 0, 0, 3, 0, 5, 0, 0, 1},
+		{0, 0, 1, 8, 0, 6, 4, 0, 0},
+		{0, 0, 8, 1, 0, 2, 9, 0, 0},
+		{7, 0, 0, 0, 0, 0, 0, 0, 8},
+		{0, 0, 6, 7, 0, 8, 2, 0, 0},
+		{0, 0, 2, 6, 0, 9, 5, 0, 0},
+		{8, 0, 0, 2, 0, 3, 0, 0, 9},
+		{0, 0, 5, 0, 1, 0, 3, 0, 0},
+	}
+	needsGuessing := [][]int{
+		{1, 0, 0, 0, 0, 0, 0, 0, 2},
+		{0, 9, 0, 4, 0, 0, 0, 5, 0},
+		{0, 0, 6, 0, 0, 0, 7, 0, 0},
+		{0, 5, 0, 9, 0, 3, 0, 0, 0},
+		{0, 0, 0, 0, 7, 0, 0, 0, 0},
+		{0, 0, 0, 8, 5, 0, 0, 4, 0},
+		{7, 0, 0, 0, 0, 0, 6, 0, 0},
+		{0, 3, 0, 0, 0, 9, 0, 8, 0},
+		{0, 0, 2, 0, 0, 0, 0, 0, 1},
+	}
+
+	tests := []struct {
+		desc   string
+		board  [][]int
+		expErr error
+	}{{
+		desc:   `already solved`,
+		board:  solved,
+		expErr: solver.ErrAlreadySolved,
+	}, {
+		desc:   `wrong number`,
+		board:  inconsistent,
+		expErr: solver.ErrInconsistentBoard,
+	}, {
+		desc:   `multiple solutions`,
+		board:  multiSolutionBoard(),
+		expErr: solver.ErrMultipleSolutions,
+	}, {
+		desc:   `needs guessing`,
+		board:  needsGuessing,
+		expErr: solver.ErrNeedsGuessing,
+	}, {
+		desc:   `wrong size`,
+		board:  solver.NewEmptyBoard(solver.WithBoxSize(2, 2)).Rows(),
+		expErr: solver.ErrWrongNumberOfRows,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := solver.Hint(mustBoard(t, tc.board))
+			require.ErrorIs(t, err, tc.expErr)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package solver
+
+// SolveLogically solves the board the way a person would, taking one step at a time and always
+// using the easiest technique that makes progress. It returns the steps in the order they were
+// taken. The steps are worked out on a copy of the board, so the solver is left unchanged. If the
+// techniques run out before the board is full, it returns the steps taken so far along with
+// ErrNeedsGuessing.
+func (s *Solver) SolveLogically() ([]Step, error) {
+	ls := newLogicSolver(s.clone())
+	var steps []Step
+	for {
+		if ls.broken() {
+			return steps, ErrNoSolution
+		}
+		if ls.solved() {
+			return steps, nil
+		}
+		step, ok := ls.next()
+		if !ok {
+			return steps, ErrNeedsGuessing
+		}
+		ls.apply(step)
+		steps = append(steps, step)
+	}
+}
+
+// logicSolver tracks the candidates of every square as techniques eliminate them. Numbers it
+// places are written to the solver, so the solver's constraints keep pruning the candidates too.
+type logicSolver struct {
+	s *Solver
+
+	// cands holds the candidates of each empty square, indexed like Solver.nums. Filled squares
+	// have none.
+	cands []digitSet
+	// units holds the squares of every unit in which each number appears exactly once: the rows,
+	// columns, and boxes, followed by the diagonals if they're enabled.
+	units [][]int
+	// unitsOf holds the units each square is in.
+	unitsOf [][]int
+	// intersections holds the boxes and lines that share at least two squares.
+	intersections []intersection
+}
+
+type intersection struct {
+	box  int
+	line int
+}
+
+func newLogicSolver(s *Solver) *logicSolver {
+	g := s.geo
+	ls := &logicSolver{
+		s:       s,
+		cands:   make([]digitSet, len(s.nums)),
+		units:   g.units,
+		unitsOf: make([][]int, len(s.nums)),
+	}
+	if s.opts.diagonals {
+		ls.units = ls.units[:len(ls.units):len(ls.units)]
+		for _, diag := range g.diagonals() {
+			unit := make([]int, len(diag))
+			for i, cell := range diag {
+				unit[i] = cell.Row*g.dim + cell.Col
+			}
+			ls.units = append(ls.units, unit)
+		}
+	}
+	for u, unit := range ls.units {
+		for _, idx := range unit {
+			ls.unitsOf[idx] = append(ls.unitsOf[idx], u)
+		}
+	}
+	for box := 2 * g.dim; box < 3*g.dim; box++ {
+		for line := range ls.units {
+			if ls.isBox(line) {
+				continue
+			}
+			shared := 0
+			for _, idx := range ls.units[box] {
+				if ls.inUnit(idx, line) {
+					shared++
+				}
+			}
+			if shared >= 2 {
+				ls.intersections = append(ls.intersections, intersection{box: box, line: line})
+			}
+		}
+	}
+	for idx, n := range s.nums {
+		if n == Empty {
+			ls.cands[idx] = s.cache.candidates(idx/g.dim, idx%g.dim)
+		}
+	}
+	return ls
+}
+
+func (ls *logicSolver) isBox(u int) bool {
+	dim := ls.s.geo.dim
+	return u >= 2*dim && u < 3*dim
+}
+
+func (ls *logicSolver) inUnit(idx, u int) bool {
+	for _, v := range ls.unitsOf[idx] {
+		if v == u {
+			return true
+		}
+	}
+	return false
+}
+
+// sees reports whether two different squares share a unit.
+func (ls *logicSolver) sees(a, b int) bool {
+	if a == b {
+		return false
+	}
+	for _, u := range ls.unitsOf[a] {
+		if ls.inUnit(b, u) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ls *logicSolver) solved() bool {
+	for _, n := range ls.s.nums {
+		if n == Empty {
+			return false
+		}
+	}
+	return true
+}
+
+// broken reports whether the board can no longer be completed: some empty square has no
+// candidates, or some unit has nowhere left for a number.
+func (ls *logicSolver) broken() bool {
+	for idx, n := range ls.s.nums {
+		if n == Empty && ls.cands[idx] == 0 {
+			return true
+		}
+	}
+	for _, unit := range ls.units {
+		var seen digitSet
+		for _, idx := range unit {
+			seen |= digitBit(ls.s.nums[idx]) | ls.cands[idx]
+		}
+		if seen&ls.s.geo.allDigits != ls.s.geo.allDigits {
+			return true
+		}
+	}
+	return false
+}
+
+// next returns a step using the easiest technique that makes progress, or false if none does.
+func (ls *logicSolver) next() (Step, bool) {
+	for t := NakedSingle; t <= XYWing; t++ {
+		if step, ok := ls.find(t); ok {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// find returns the first step that the technique makes, or false if it makes no progress.
+func (ls *logicSolver) find(t Technique) (Step, bool) {
+	switch t {
+	case NakedSingle:
+		return ls.nakedSingle()
+	case HiddenSingle:
+		return ls.hiddenSingle()
+	case PointingPair, BoxLineReduction:
+		return ls.intersectionRemoval(t)
+	case NakedPair:
+		return ls.nakedSubset(t, 2)
+	case NakedTriple:
+		return ls.nakedSubset(t, 3)
+	case HiddenPair:
+		return ls.hiddenSubset(t, 2)
+	case HiddenTriple:
+		return ls.hiddenSubset(t, 3)
+	case XWing:
+		return ls.fish(t, 2)
+	case Swordfish:
+		return ls.fish(t, 3)
+	case XYWing:
+		return ls.xyWing()
+	}
+	return Step{}, false
+}
+
+func (ls *logicSolver) apply(step Step) {
+	for _, p := range step.Placements {
+		ls.place(p.Row, p.Col, p.Digit)
+	}
+	for _, e := range step.Eliminations {
+		ls.cands[e.Row*ls.s.geo.dim+e.Col] &^= digitBit(e.Digit)
+	}
+}
+
+// place writes n at (r, c) and prunes the candidates of the other squares to what the solver's
+// constraints still allow.
+func (ls *logicSolver) place(r, c, n int) {
+	dim := ls.s.geo.dim
+	ls.s.writeAt(r, c, n)
+	ls.cands[r*dim+c] = 0
+	for idx, cands := range ls.cands {
+		if cands != 0 {
+			ls.cands[idx] = cands & ls.s.cache.candidates(idx/dim, idx%dim)
+		}
+	}
+}
+
+func (ls *logicSolver) cell(idx int) Cell {
+	pt := ls.s.geo.points[idx]
+	return Cell{Row: pt.row, Col: pt.col}
+}
+
+func (ls *logicSolver) cellsOf(idxs []int) []Cell {
+	res := make([]Cell, len(idxs))
+	for i, idx := range idxs {
+		res[i] = ls.cell(idx)
+	}
+	return res
+}
+
+func (ls *logicSolver) candidate(idx, n int) Candidate {
+	pt := ls.s.geo.points[idx]
+	return Candidate{Row: pt.row, Col: pt.col, Digit: n}
+}
+
+func (ls *logicSolver) nakedSingle() (Step, bool) {
+	for idx, cands := range ls.cands {
+		if cands.len() == 1 {
+			return Step{
+				Technique:  NakedSingle,
+				Cells:      []Cell{ls.cell(idx)},
+				Placements: []Candidate{ls.candidate(idx, cands.digits()[0])},
+			}, true
+		}
+	}
+	return Step{}, false
+}
+
+func (ls *logicSolver) hiddenSingle() (Step, bool) {
+	for _, unit := range ls.units {
+		for n := MinEntry; n <= ls.s.geo.dim; n++ {
+			found, count := -1, 0
+			for _, idx := range unit {
+				if ls.cands[idx].has(n) {
+					found = idx
+					count++
+				}
+			}
+			if count == 1 {
+				return Step{
+					Technique:  HiddenSingle,
+					Cells:      ls.cellsOf(unit),
+					Placements: []Candidate{ls.candidate(found, n)},
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// intersectionRemoval finds a number whose candidates in one unit all lie where it meets another,
+// and removes the number from the rest of the other unit. Pointing pairs look from a box to a
+// line, and box/line reductions from a line to a box.
+func (ls *logicSolver) intersectionRemoval(t Technique) (Step, bool) {
+	for _, in := range ls.intersections {
+		from, to := in.box, in.line
+		if t == BoxLineReduction {
+			from, to = in.line, in.box
+		}
+		for n := MinEntry; n <= ls.s.geo.dim; n++ {
+			var inside []int
+			confined := true
+			for _, idx := range ls.units[from] {
+				if !ls.cands[idx].has(n) {
+					continue
+				}
+				if !ls.inUnit(idx, to) {
+					confined = false
+					break
+				}
+				inside = append(inside, idx)
+			}
+			if !confined || len(inside) == 0 {
+				continue
+			}
+			var elims []Candidate
+			for _, idx := range ls.units[to] {
+				if ls.cands[idx].has(n) && !ls.inUnit(idx, from) {
+					elims = append(elims, ls.candidate(idx, n))
+				}
+			}
+			if len(elims) != 0 {
+				return Step{
+					Technique:    t,
+					Cells:        ls.cellsOf(inside),
+					Eliminations: elims,
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// eachCombination calls fn with every k-element subset of 0 through n-1, in lexicographic order,
+// until fn returns true. It reports whether fn did.
+func eachCombination(n, k int, fn func(pick []int) bool) bool {
+	pick := make([]int, k)
+	var rec func(i, start int) bool
+	rec = func(i, start int) bool {
+		if i == k {
+			return fn(pick)
+		}
+		for j := start; j <= n-(k-i); j++ {
+			pick[i] = j
+			if rec(i+1, j+1) {
+				return true
+			}
+		}
+		return false
+	}
+	return rec(0, 0)
+}
@@ -0,0 +1,189 @@
+package solver_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestSolveLogically(t *testing.T) {
+	tests := []struct {
+		desc       string
+		board      [][]int
+		expHardest solver.Technique
+	}{{
+		desc: `singles only`,
+		board: [][]int{
+			{0, 0, 3, 0, 2, 0, 6, 0, 0},
+			{9, 0, 0, 3, 0, 5, 0, 0, 1},
+			{0, 0, 1, 8, 0, 6, 4, 0, 0},
+			{0, 0, 8, 1, 0, 2, 9, 0, 0},
+			{7, 0, 0, 0, 0, 0, 0, 0, 8},
+			{0, 0, 6, 7, 0, 8, 2, 0, 0},
+			{0, 0, 2, 6, 0, 9, 5, 0, 0},
+			{8, 0, 0, 2, 0, 3, 0, 0, 9},
+			{0, 0, 5, 0, 1, 0, 3, 0, 0},
+		},
+		expHardest: solver.NakedSingle,
+	}, {
+		desc: `x-wing`,
+		board: [][]int{
+			{0, 4, 3, 0, 8, 0, 2, 5, 0},
+			{6, 0, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 1, 0, 9, 4},
+			{9, 0, 0, 0, 0, 4, 0, 7, 0},
+			{0, 0, 0, 6, 0, 8, 0, 0, 0},
+			{0, 1, 0, 2, 0, 0, 0, 0, 3},
+			{8, 2, 0, 5, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0, 0, 0, 5},
+			{0, 3, 4, 0, 9, 0, 7, 1, 0},
+		},
+		expHardest: solver.XWing,
+	}, {
+		desc: `swordfish`,
+		board: [][]int{
+			{5, 2, 9, 4, 1, 0, 7, 0, 3},
+			{0, 0, 6, 0, 0, 3, 0, 0, 2},
+			{0, 0, 3, 2, 0, 0, 0, 0, 0},
+			{0, 5, 2, 3, 0, 0, 0, 7, 6},
+			{6, 3, 7, 0, 5, 0, 2, 0, 0},
+			{1, 9, 0, 6, 2, 7, 5, 3, 0},
+			{3, 0, 0, 0, 6, 9, 4, 2, 0},
+			{2, 0, 0, 8, 3, 0, 6, 0, 0},
+			{9, 6, 0, 7, 4, 2, 3, 0, 5},
+		},
+		expHardest: solver.Swordfish,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := newSolver(tc.board)
+			require.NoError(t, err)
+			solved, err := s.Solve()
+			require.NoError(t, err)
+
+			s, err = newSolver(tc.board)
+			require.NoError(t, err)
+			steps, err := s.SolveLogically()
+			require.NoError(t, err)
+			require.Equal(t, mustBoard(t, tc.board), s.ToBoard())
+			requireSoundSteps(t, solved, steps)
+
+			// the steps' placements fill in the rest of the board
+			board := mustBoard(t, tc.board)
+			for _, step := range steps {
+				for _, p := range step.Placements {
+					board.Set(p.Row, p.Col, p.Digit)
+				}
+			}
+			require.Equal(t, solved, board)
+
+			var hardest solver.Technique
+			for _, step := range steps {
+				if step.Technique > hardest {
+					hardest = step.Technique
+				}
+			}
+			require.Equal(t, tc.expHardest, hardest)
+		})
+	}
+}
+
+func TestSolveLogicallyNeedsGuessing(t *testing.T) {
+	input := [][]int{
+		{4, 8, 0, 3, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 7, 1},
+		{0, 2, 0, 0, 0, 0, 0, 0, 0},
+		{7, 0, 5, 0, 0, 0, 0, 6, 0},
+		{0, 0, 0, 2, 0, 0, 8, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 0, 7, 6, 0, 0, 0},
+		{3, 0, 0, 0, 0, 0, 4, 0, 0},
+		{0, 0, 0, 0, 5, 0, 0, 0, 0},
+	}
+	s, err := newSolver(input)
+	require.NoError(t, err)
+	solved, err := s.Solve()
+	require.NoError(t, err)
+
+	s, err = newSolver(input)
+	require.NoError(t, err)
+	steps, err := s.SolveLogically()
+	require.Equal(t, solver.ErrNeedsGuessing, err)
+	require.NotEmpty(t, steps)
+	requireSoundSteps(t, solved, steps)
+
+	// the solver still searches the board it was given
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, solved, actual)
+}
+
+func TestTechniqueString(t *testing.T) {
+	require.Equal(t, `Naked Single`, solver.NakedSingle.String())
+	require.Equal(t, `XY-Wing`, solver.XYWing.String())
+	require.Equal(t, `Unknown Technique`, solver.Technique(0).String())
+}
+
+// requireSoundSteps checks that every step agrees with the solution: placed numbers match it and
+// eliminated candidates don't.
+func requireSoundSteps(t *testing.T, solved solver.Board, steps []solver.Step) {
+	for _, step := range steps {
+		require.NotEmpty(t, step.Cells)
+		// a step either places numbers or eliminates candidates, not both
+		require.NotEqual(t, len(step.Placements) == 0, len(step.Eliminations) == 0)
+		for _, p := range step.Placements {
+			require.Equal(t, solved.Get(p.Row, p.Col), p.Digit, `%s placed a wrong number`, step.Technique)
+		}
+		for _, e := range step.Eliminations {
+			require.NotEqual(t, solved.Get(e.Row, e.Col), e.Digit, `%s eliminated the solution`, step.Technique)
+		}
+	}
+}
+
+func TestStepJSON(t *testing.T) {
+	step := solver.Step{
+		Technique:  solver.HiddenSingle,
+		Cells:      []solver.Cell{{Row: 0, Col: 1}},
+		Placements: []solver.Candidate{{Row: 0, Col: 1, Digit: 2}},
+	}
+	bs, err := json.Marshal(step)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"technique": "Hidden Single",
+		"cells": [{"row": 0, "col": 1}],
+		"placements": [{"row": 0, "col": 1, "digit": 2}]
+	}`, string(bs))
+
+	var decoded solver.Step
+	require.NoError(t, json.Unmarshal(bs, &decoded))
+	require.Equal(t, step, decoded)
+
+	require.Error(t, json.Unmarshal([]byte(`{"technique": "Guessing"}`), &decoded))
+	_, err = json.Marshal(solver.Step{Technique: solver.XYWing + 1})
+	require.Error(t, err)
+
+	// a rating of a solved board has no hardest technique, which is still encoded
+	bs, err = json.Marshal(solver.Rating{})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tier": "", "score": 0, "hardest": "", "steps": 0}`, string(bs))
+	var rating solver.Rating
+	require.NoError(t, json.Unmarshal(bs, &rating))
+	require.Equal(t, solver.Rating{}, rating)
+
+	// tiers are encoded by name too
+	expected := solver.Rating{Tier: solver.Hard, Score: 812, Hardest: solver.NakedPair, Steps: 60}
+	bs, err = json.Marshal(expected)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tier": "hard", "score": 812, "hardest": "Naked Pair", "steps": 60}`, string(bs))
+	require.NoError(t, json.Unmarshal(bs, &rating))
+	require.Equal(t, expected, rating)
+
+	require.Error(t, json.Unmarshal([]byte(`{"tier": "impossible"}`), &rating))
+	_, err = json.Marshal(solver.Rating{Tier: solver.Expert + 1})
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package solver
+
+import "context"
+
+// Minimize removes redundant clues from a board with a unique solution until every remaining clue
+// is needed to keep it unique. Clues are tried in reading order, so the result is always the same
+// for the same board. It returns the reduced board and the clues it removed, leaving board
+// unmodified. It fails with ErrNoSolution or ErrMultipleSolutions unless the board has exactly one
+// solution, and with ErrBudgetExceeded if any search runs out of the node budget.
+func Minimize(board Board, opts ...Option) (Board, []Candidate, error) {
+	return MinimizeContext(context.Background(), board, opts...)
+}
+
+// MinimizeContext is like Minimize, but gives up with ErrCanceled once ctx is done.
+func MinimizeContext(ctx context.Context, board Board, opts ...Option) (Board, []Candidate, error) {
+	groups := make([][]Cell, 0, board.Size()*board.Size())
+	for r := 0; r < board.Size(); r++ {
+		for c := 0; c < board.Size(); c++ {
+			groups = append(groups, []Cell{{Row: r, Col: c}})
+		}
+	}
+	return MinimizeGroupsContext(ctx, board, groups, nil, opts...)
+}
+
+// MinimizeGroups is like Minimize, but tries removing clues a group at a time, in the order of
+// groups. The clues of a group are removed together or not at all, so groups made of symmetric
+// squares keep a symmetric board symmetric. Squares in no group, or off the board, keep their
+// clues. If keep isn't nil, it's given each board a removal would leave before the board is checked
+// for a unique solution, and the removal is undone unless it returns true.
+func MinimizeGroups(board Board, groups [][]Cell, keep func(Board) bool, opts ...Option) (Board, []Candidate, error) {
+	return MinimizeGroupsContext(context.Background(), board, groups, keep, opts...)
+}
+
+// MinimizeGroupsContext is like MinimizeGroups, but gives up with ErrCanceled once ctx is done.
+func MinimizeGroupsContext(ctx context.Context, board Board, groups [][]Cell, keep func(Board) bool, opts ...Option) (Board, []Candidate, error) {
+	s, err := newUniqueSolver(ctx, board, ErrNoSolution, opts)
+	if err != nil {
+		return Board{}, nil, err
+	}
+
+	var removed []Candidate
+	for _, group := range groups {
+		var clears []Candidate
+		for _, cell := range group {
+			if cell.Row < 0 || cell.Row >= s.geo.dim || cell.Col < 0 || cell.Col >= s.geo.dim {
+				continue
+			}
+			n := s.nums[cell.Row*s.geo.dim+cell.Col]
+			if n == Empty || containsCell(clears, cell) {
+				continue
+			}
+			clears = append(clears, Candidate{Row: cell.Row, Col: cell.Col, Digit: n})
+		}
+		if len(clears) == 0 {
+			continue
+		}
+		for _, cand := range clears {
+			s.clearAt(cand.Row, cand.Col, cand.Digit)
+		}
+		ok := keep == nil || keep(s.ToBoard())
+		if ok {
+			if ok, err = s.IsUniqueContext(ctx); err != nil {
+				return Board{}, nil, err
+			}
+		}
+		if !ok {
+			// the clues are needed
+			for _, cand := range clears {
+				s.writeAt(cand.Row, cand.Col, cand.Digit)
+			}
+			continue
+		}
+		removed = append(removed, clears...)
+	}
+	return s.ToBoard(), removed, nil
+}
+
+// containsCell reports whether any of the candidates is in the cell.
+func containsCell(cands []Candidate, cell Cell) bool {
+	for _, cand := range cands {
+		if cand.Row == cell.Row && cand.Col == cell.Col {
+			return true
+		}
+	}
+	return false
+}
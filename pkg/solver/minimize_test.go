@@ -0,0 +1,150 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestMinimize(t *testing.T) {
+	input := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	orig := mustBoard(t, input)
+
+	res, removed, err := solver.Minimize(orig)
+	require.NoError(t, err)
+
+	// the removed clues are exactly the ones missing from the result
+	restored := res
+	for _, cand := range removed {
+		require.Equal(t, solver.Empty, res.Get(cand.Row, cand.Col))
+		require.Equal(t, orig.Get(cand.Row, cand.Col), cand.Digit)
+		restored.Set(cand.Row, cand.Col, cand.Digit)
+	}
+	require.Equal(t, orig, restored)
+
+	s, err := solver.New(res)
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+	solved, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, orig, solved)
+
+	// every remaining clue is needed
+	for r := 0; r < res.Size(); r++ {
+		for c := 0; c < res.Size(); c++ {
+			if res.Get(r, c) == solver.Empty {
+				continue
+			}
+			without := res
+			without.Set(r, c, solver.Empty)
+			s, err := solver.New(without, solver.WithStrategy(solver.MostConstrained))
+			require.NoError(t, err)
+			require.False(t, s.IsUnique(), `clue at (%d, %d) is redundant`, r, c)
+		}
+	}
+
+	// minimizing is deterministic
+	again, _, err := solver.Minimize(orig)
+	require.NoError(t, err)
+	require.Equal(t, res, again)
+}
+
+func TestMinimizeVariant(t *testing.T) {
+	input := [][]int{
+		{4, 6, 3, 7, 5, 2, 1, 9, 8},
+		{1, 9, 8, 4, 6, 3, 7, 5, 2},
+		{7, 5, 2, 1, 9, 8, 4, 6, 3},
+		{2, 3, 1, 6, 4, 9, 8, 7, 5},
+		{8, 4, 9, 5, 3, 7, 6, 2, 1},
+		{5, 7, 6, 2, 8, 1, 9, 3, 4},
+		{3, 2, 7, 8, 1, 6, 5, 4, 9},
+		{9, 1, 4, 3, 7, 5, 2, 8, 6},
+		{6, 8, 5, 9, 2, 4, 3, 1, 7},
+	}
+	classic, _, err := solver.Minimize(mustBoard(t, input))
+	require.NoError(t, err)
+	diagonal, _, err := solver.Minimize(mustBoard(t, input), solver.WithDiagonals())
+	require.NoError(t, err)
+
+	// the diagonals do some of the work of the clues
+	require.Less(t, diagonal.Givens(), classic.Givens())
+	s, err := solver.New(diagonal, solver.WithDiagonals(), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+}
+
+func TestMinimizeErrors(t *testing.T) {
+	_, _, err := solver.Minimize(mustBoard(t, multiSolutionBoard()))
+	require.Equal(t, solver.ErrMultipleSolutions, err)
+
+	noSolution := multiSolutionBoard()
+	noSolution[0][0], noSolution[0][1] = 5, 3
+	_, _, err = solver.Minimize(mustBoard(t, noSolution))
+	require.Equal(t, solver.ErrNoSolution, err)
+
+	_, _, err = solver.Minimize(solver.NewEmptyBoard(solver.WithBoxSize(2, 2)))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+}
+
+func TestMinimizeGroups(t *testing.T) {
+	input := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	orig := mustBoard(t, input)
+
+	// each square is grouped with its mirror image through the center; cells off the board are
+	// ignored
+	var groups [][]solver.Cell
+	for r := 0; r < solver.Dimension; r++ {
+		for c := 0; c < solver.Dimension; c++ {
+			groups = append(groups, []solver.Cell{{Row: r, Col: c}, {Row: 8 - r, Col: 8 - c}})
+		}
+	}
+	groups = append(groups, []solver.Cell{{Row: -1, Col: 0}, {Row: 0, Col: 9}})
+	keep := func(b solver.Board) bool { return b.Givens() >= 30 }
+	res, removed, err := solver.MinimizeGroups(orig, groups, keep)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, res.Givens(), 30)
+	require.Equal(t, solver.TotalSquares-len(removed), res.Givens())
+	for r := 0; r < res.Size(); r++ {
+		for c := 0; c < res.Size(); c++ {
+			require.Equal(t, res.Get(r, c) == solver.Empty, res.Get(8-r, 8-c) == solver.Empty)
+		}
+	}
+
+	s, err := solver.New(res, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+
+	// without any groups, nothing is removed
+	res, removed, err = solver.MinimizeGroups(orig, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, orig, res)
+	require.Empty(t, removed)
+
+	// nor is anything removed that keep refuses
+	res, removed, err = solver.MinimizeGroups(orig, groups, func(solver.Board) bool { return false })
+	require.NoError(t, err)
+	require.Equal(t, orig, res)
+	require.Empty(t, removed)
+}
@@ -0,0 +1,13 @@
+package solver
+
+// Observer watches a search fill in the board, for example to animate or trace it. A search never
+// calls its observer from more than one goroutine at a time.
+type Observer interface {
+	// OnPlace is called after the search guesses n for the square at row r and column c.
+	OnPlace(r, c, n int)
+	// OnBacktrack is called after the search erases its guess of n from the square at row r and
+	// column c, either to try the next guess or because the search is ending.
+	OnBacktrack(r, c, n int)
+	// OnSolved is called with each solution the search finds.
+	OnSolved(board Board)
+}
@@ -0,0 +1,82 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+// replayObserver replays the events of a search on its own copy of the board.
+type replayObserver struct {
+	t         *testing.T
+	board     solver.Board
+	places    int
+	solutions []solver.Board
+}
+
+func (ro *replayObserver) OnPlace(r, c, n int) {
+	require.Equal(ro.t, solver.Empty, ro.board.Get(r, c))
+	ro.board.Set(r, c, n)
+	ro.places++
+}
+
+func (ro *replayObserver) OnBacktrack(r, c, n int) {
+	require.Equal(ro.t, n, ro.board.Get(r, c))
+	ro.board.Set(r, c, solver.Empty)
+}
+
+func (ro *replayObserver) OnSolved(board solver.Board) {
+	ro.solutions = append(ro.solutions, board)
+}
+
+func TestObserver(t *testing.T) {
+	board := mustBoard(t, multiSolutionBoard())
+	obs := &replayObserver{t: t, board: board}
+	s, err := solver.New(board, solver.WithObserver(obs))
+	require.NoError(t, err)
+
+	// solving puts the board back the way it was, so the last solution found is reported to the
+	// observer before the search erases it
+	solution, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, board, obs.board)
+	require.Equal(t, []solver.Board{solution}, obs.solutions)
+	require.Equal(t, s.Stats().Nodes, obs.places)
+
+	obs = &replayObserver{t: t, board: board}
+	s, err = solver.New(board, solver.WithObserver(obs))
+	require.NoError(t, err)
+	require.Equal(t, 4, s.CountSolutions(0))
+	require.Equal(t, board, obs.board)
+	require.Len(t, obs.solutions, 4)
+	for _, sol := range obs.solutions {
+		check, err := solver.New(sol)
+		require.NoError(t, err)
+		require.Equal(t, 1, check.CountSolutions(0))
+	}
+
+	// a full board is its own solution without any guesses
+	obs = &replayObserver{t: t, board: solution}
+	s, err = solver.New(solution, solver.WithObserver(obs))
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.NoError(t, err)
+	require.Zero(t, obs.places)
+	require.Equal(t, []solver.Board{solution}, obs.solutions)
+}
+
+func TestObserverParallel(t *testing.T) {
+	board := mustBoard(t, multiSolutionBoard())
+	obs := &replayObserver{t: t, board: board}
+	s, err := solver.New(board, solver.WithObserver(obs), solver.WithParallelism(4))
+	require.NoError(t, err)
+	require.Equal(t, 4, s.CountSolutions(0))
+	require.Zero(t, obs.places)
+	require.Len(t, obs.solutions, 4)
+
+	obs.solutions = nil
+	require.Equal(t, 2, s.CountSolutions(2))
+	require.Len(t, obs.solutions, 2)
+}
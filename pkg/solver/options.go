@@ -0,0 +1,123 @@
+package solver
+
+// Strategy decides which empty square the solver fills in next while searching.
+type Strategy int
+
+const (
+	// Sequential fills in empty squares in reading order.
+	Sequential Strategy = iota
+
+	// MostConstrained fills in the empty square with the fewest valid entries next. Squares with a
+	// single valid entry are forced moves and are filled in as soon as they're found, and a square
+	// with no valid entries ends the current line of search immediately.
+	MostConstrained
+)
+
+type options struct {
+	strategy    Strategy
+	boxRows     int
+	boxCols     int
+	regions     [][]int
+	constraints []Constraint
+	cages       []Cage
+	diagonals   bool
+	nodeBudget  int
+	workers     int
+	observer    Observer
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		boxRows: 3,
+		boxCols: 3,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) validBoxSize() bool {
+	return o.boxRows > 0 && o.boxCols > 0 && o.boxRows*o.boxCols <= MaxDimension
+}
+
+// Option configures a Solver.
+type Option func(*options)
+
+// WithBoxSize sets the size of the board's boxes to rows squares tall by cols squares wide. The
+// board then has rows*cols rows and columns, and takes entries from 1 to rows*cols. The default is
+// 3 by 3, for a classic 9x9 board.
+func WithBoxSize(rows, cols int) Option {
+	return func(o *options) {
+		o.boxRows = rows
+		o.boxCols = cols
+	}
+}
+
+// WithStrategy sets the search strategy. The default is Sequential.
+func WithStrategy(st Strategy) Option {
+	return func(o *options) {
+		o.strategy = st
+	}
+}
+
+// WithRegions replaces the board's boxes with irregular regions, as in jigsaw sudoku. regions maps
+// every square of the board to the region it belongs to, numbered from 0. There must be as many
+// regions as the board has rows, and each must have that many squares.
+func WithRegions(regions [][]int) Option {
+	return func(o *options) {
+		o.regions = regions
+	}
+}
+
+// WithConstraints adds rules for the solution to follow on top of the classic ones. Each solver
+// works with its own clones of the constraints, so they can be shared between solvers.
+func WithConstraints(cs ...Constraint) Option {
+	return func(o *options) {
+		for _, con := range cs {
+			o.constraints = append(o.constraints, con.Clone())
+		}
+	}
+}
+
+// WithCages adds killer sudoku cages to the board. Cages may not overlap, but they don't need to
+// cover the whole board.
+func WithCages(cages ...Cage) Option {
+	return func(o *options) {
+		o.cages = append(o.cages, cages...)
+	}
+}
+
+// WithDiagonals adds the board's two main diagonals as units in which numbers can't repeat, as in
+// Sudoku-X.
+func WithDiagonals() Option {
+	return func(o *options) {
+		o.diagonals = true
+	}
+}
+
+// WithNodeBudget limits a search to n guesses, after which it gives up with ErrBudgetExceeded. A
+// budget of zero or less, the default, doesn't limit the search. Parallel workers share the budget
+// and only check it every so often, so together they may go slightly over it.
+func WithNodeBudget(n int) Option {
+	return func(o *options) {
+		o.nodeBudget = n
+	}
+}
+
+// WithParallelism splits searches for a solution or for the number of solutions across the given
+// number of goroutines. The default of one searches on the calling goroutine. Enumerating solutions
+// with Solutions always searches on the calling goroutine.
+func WithParallelism(workers int) Option {
+	return func(o *options) {
+		o.workers = workers
+	}
+}
+
+// WithObserver reports every step of the solver's searches to obs. Searches split across goroutines
+// with WithParallelism only report the solutions they find.
+func WithObserver(obs Observer) Option {
+	return func(o *options) {
+		o.observer = obs
+	}
+}
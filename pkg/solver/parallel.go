@@ -0,0 +1,185 @@
+package solver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// jobsPerWorker is roughly how many pieces of the search each worker gets. Having more pieces than
+// workers keeps them all busy when some pieces turn out much larger than others.
+const jobsPerWorker = 8
+
+// clone returns an independent copy of the solver in its current state, without its observer.
+func (s *Solver) clone() *Solver {
+	res := &Solver{
+		nums:  append([]int(nil), s.nums...),
+		geo:   s.geo,
+		cache: s.cache.clone(),
+		opts:  s.opts,
+	}
+	res.opts.observer = nil
+	return res
+}
+
+// split breaks the search up into at least target pieces, if there are that many, by expanding the
+// top levels of the search tree. Each piece is a list of guesses to make before searching the rest
+// of the board. The guesses count towards the solver's statistics, and the solver is left in its
+// original state.
+func (s *Solver) split(target int) [][]guess {
+	pieces := [][]guess{nil}
+	for len(pieces) < target {
+		var next [][]guess
+		expanded := false
+		for _, piece := range pieces {
+			for _, g := range piece {
+				s.writeAt(g.idx/s.geo.dim, g.idx%s.geo.dim, g.n)
+			}
+			idx := s.nextSquare(0)
+			if idx < 0 {
+				// the guesses complete the board
+				next = append(next, piece)
+			} else {
+				expanded = true
+				r, c := idx/s.geo.dim, idx%s.geo.dim
+				cands := s.cache.candidates(r, c)
+				if cands == 0 {
+					s.stats.Backtracks++
+				}
+				for _, n := range cands.digits() {
+					child := append(piece[:len(piece):len(piece)], guess{idx: idx, n: n})
+					next = append(next, child)
+					s.stats.Nodes++
+					s.stats.Guesses[r][c]++
+				}
+				if len(piece)+1 > s.stats.MaxDepth {
+					s.stats.MaxDepth = len(piece) + 1
+				}
+			}
+			for i := len(piece) - 1; i >= 0; i-- {
+				s.clearAt(piece[i].idx/s.geo.dim, piece[i].idx%s.geo.dim, piece[i].n)
+			}
+		}
+		pieces = next
+		if !expanded {
+			break
+		}
+	}
+	return pieces
+}
+
+// searchParallel counts solutions across the solver's workers, stopping once limit solutions have
+// been found. A limit of zero or less counts every solution. It returns the count along with the
+// first solution found, and leaves the solver in its original state. The solver's statistics add up
+// the work of every worker.
+func (s *Solver) searchParallel(parent context.Context, limit int) (int, Board, error) {
+	start := time.Now()
+	s.resetStats()
+	defer func() {
+		s.stats.Elapsed = time.Since(start)
+	}()
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	pieces := s.split(s.opts.workers * jobsPerWorker)
+	if budget := s.opts.nodeBudget; budget > 0 && s.stats.Nodes > budget {
+		return 0, Board{}, ErrBudgetExceeded
+	}
+	jobs := make(chan []guess)
+	go func() {
+		defer close(jobs)
+		for _, piece := range pieces {
+			select {
+			case jobs <- piece:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		count int64
+		// the guesses made splitting up the search count towards the budget too
+		nodes = int64(s.stats.Nodes)
+
+		mu       sync.Mutex
+		first    Board
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < s.opts.workers; w++ {
+		wg.Add(1)
+		go func(ws *Solver) {
+			defer wg.Done()
+			for piece := range jobs {
+				for _, g := range piece {
+					ws.writeAt(g.idx/ws.geo.dim, g.idx%ws.geo.dim, g.n)
+				}
+				sols := ws.SolutionsContext(ctx)
+				sols.sharedNodes = &nodes
+				for sols.advance() {
+					n := atomic.AddInt64(&count, 1)
+					if n == 1 {
+						mu.Lock()
+						first = ws.ToBoard()
+						mu.Unlock()
+					}
+					if obs := s.opts.observer; obs != nil && (limit <= 0 || n <= int64(limit)) {
+						mu.Lock()
+						obs.OnSolved(ws.ToBoard())
+						mu.Unlock()
+					}
+					if limit > 0 && n >= int64(limit) {
+						// the other workers have nothing left to find
+						cancel()
+						break
+					}
+				}
+				if sols.Err() == nil {
+					// guesses since the last multiple of cancelCheckInterval haven't been shared yet
+					sols.shareNodes(ws.stats.Nodes % cancelCheckInterval)
+				}
+				sols.Stop()
+				mu.Lock()
+				s.stats.merge(ws.stats, len(piece))
+				err := sols.Err()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				if err != nil {
+					cancel()
+				}
+				for i := len(piece) - 1; i >= 0; i-- {
+					ws.clearAt(piece[i].idx/ws.geo.dim, piece[i].idx%ws.geo.dim, piece[i].n)
+				}
+			}
+		}(s.clone())
+	}
+	wg.Wait()
+
+	total := int(count)
+	if limit > 0 && total >= limit {
+		// reaching the limit cancels the other workers, which isn't an error
+		return limit, first, nil
+	}
+	if err := parent.Err(); err != nil && firstErr == nil {
+		// the workers may have stopped before taking any pieces
+		firstErr = canceledError{cause: err}
+	}
+	return total, first, firstErr
+}
+
+// solveParallel finds a solution across the solver's workers. When the board has several
+// solutions, any of them may be the one found.
+func (s *Solver) solveParallel(ctx context.Context) (Board, error) {
+	count, first, err := s.searchParallel(ctx, 1)
+	if err != nil {
+		return Board{}, err
+	}
+	if count == 0 {
+		return Board{}, ErrNoSolution
+	}
+	return first, nil
+}
@@ -0,0 +1,104 @@
+package solver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestSolveParallel(t *testing.T) {
+	input := hardBoard()
+	s, err := newSolver(input, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	expected, err := s.Solve()
+	require.NoError(t, err)
+
+	s, err = newSolver(input, solver.WithStrategy(solver.MostConstrained), solver.WithParallelism(4))
+	require.NoError(t, err)
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+	require.True(t, s.IsUnique())
+}
+
+func TestCountSolutionsParallel(t *testing.T) {
+	// with the middle rows cleared, the board has dozens of solutions
+	board := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	s, err := newSolver(board)
+	require.NoError(t, err)
+	expected := s.CountSolutions(0)
+	require.Greater(t, expected, 10)
+
+	for _, workers := range []int{2, 4, 8} {
+		s, err := newSolver(board, solver.WithParallelism(workers))
+		require.NoError(t, err)
+		require.Equal(t, expected, s.CountSolutions(0))
+		require.Equal(t, 10, s.CountSolutions(10))
+		require.Equal(t, mustBoard(t, board), s.ToBoard())
+	}
+
+	s, err = newSolver(multiSolutionBoard(), solver.WithParallelism(4))
+	require.NoError(t, err)
+	require.Equal(t, 4, s.CountSolutions(0))
+	require.False(t, s.IsUnique())
+}
+
+func TestParallelErrors(t *testing.T) {
+	s, err := solver.New(solver.NewEmptyBoard(), solver.WithParallelism(4))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.SolveContext(ctx)
+	require.ErrorIs(t, err, solver.ErrCanceled)
+	require.Equal(t, solver.NewEmptyBoard(), s.ToBoard())
+
+	s, err = solver.New(solver.NewEmptyBoard(), solver.WithParallelism(4), solver.WithNodeBudget(10000))
+	require.NoError(t, err)
+	count, err := s.CountSolutionsContext(context.Background(), 0)
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+	require.Greater(t, count, 0)
+	require.Equal(t, solver.NewEmptyBoard(), s.ToBoard())
+
+	noSolution := multiSolutionBoard()
+	noSolution[0][0], noSolution[0][1] = 5, 3
+	s, err = newSolver(noSolution, solver.WithParallelism(4))
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.Equal(t, solver.ErrNoSolution, err)
+}
+
+func TestParallelBudgetSmallPieces(t *testing.T) {
+	// the search splits into pieces that each take far fewer guesses than the check interval, so
+	// the budget only holds if every piece's guesses are counted
+	s, err := newSolver(hardBoard(), solver.WithStrategy(solver.MostConstrained), solver.WithNodeBudget(930))
+	require.NoError(t, err)
+	_, err = s.CountSolutionsContext(context.Background(), 0)
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+
+	s, err = newSolver(hardBoard(), solver.WithStrategy(solver.MostConstrained), solver.WithParallelism(8),
+		solver.WithNodeBudget(930))
+	require.NoError(t, err)
+	_, err = s.CountSolutionsContext(context.Background(), 0)
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+	require.Equal(t, mustBoard(t, hardBoard()), s.ToBoard())
+
+	// a budget the split alone goes over fails before any worker starts
+	s, err = newSolver(hardBoard(), solver.WithStrategy(solver.MostConstrained), solver.WithParallelism(8),
+		solver.WithNodeBudget(1))
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.Equal(t, solver.ErrBudgetExceeded, err)
+}
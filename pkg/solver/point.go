@@ -1,120 +1,264 @@
 package solver
 
+import (
+	"fmt"
+	"math/bits"
+)
+
 type point struct {
 	row int
 	col int
 	box int
 }
 
-func newPoint(r, c int) point {
-	return point{
-		row: r,
-		col: c,
-		box: 3*(r/3) + c/3,
+// geometry describes the shape of a board. Boxes are boxRows squares tall and boxCols squares wide,
+// and the board has as many rows, columns, boxes, and valid entries as a box has squares.
+type geometry struct {
+	boxRows int
+	boxCols int
+	dim     int
+
+	// points holds the row, column, and box of every square, indexed like Solver.nums.
+	points []point
+	// units holds the squares of every row, column, and box, in that order. Irregular regions take
+	// the place of boxes.
+	units [][]int
+	// allDigits contains every valid entry.
+	allDigits digitSet
+}
+
+func newGeometry(boxRows, boxCols int) *geometry {
+	dim := boxRows * boxCols
+	g := &geometry{
+		boxRows:   boxRows,
+		boxCols:   boxCols,
+		dim:       dim,
+		points:    make([]point, dim*dim),
+		units:     make([][]int, 3*dim),
+		allDigits: (1<<uint(dim+1) - 1) &^ 1,
+	}
+	for i := range g.units {
+		g.units[i] = make([]int, 0, dim)
+	}
+	for r := 0; r < dim; r++ {
+		for c := 0; c < dim; c++ {
+			idx := r*dim + c
+			pt := point{
+				row: r,
+				col: c,
+				box: boxRows*(r/boxRows) + c/boxCols,
+			}
+			g.points[idx] = pt
+			g.units[pt.row] = append(g.units[pt.row], idx)
+			g.units[dim+pt.col] = append(g.units[dim+pt.col], idx)
+			g.units[2*dim+pt.box] = append(g.units[2*dim+pt.box], idx)
+		}
+	}
+	return g
+}
+
+// setRegions replaces the boxes with irregular regions. It fails if the regions don't cover the
+// board with dim regions of dim squares each.
+func (g *geometry) setRegions(regions [][]int) error {
+	if len(regions) != g.dim {
+		return fmt.Errorf(`%w: expected %d rows`, ErrInvalidRegions, g.dim)
+	}
+	sizes := make([]int, g.dim)
+	for r, row := range regions {
+		if len(row) != g.dim {
+			return fmt.Errorf(`%w: expected %d cols`, ErrInvalidRegions, g.dim)
+		}
+		for c, region := range row {
+			if region < 0 || region >= g.dim {
+				return fmt.Errorf(`%w: region %d at (%d, %d) is out of range`, ErrInvalidRegions, region, r, c)
+			}
+			sizes[region]++
+		}
+	}
+	for region, size := range sizes {
+		if size != g.dim {
+			return fmt.Errorf(`%w: region %d has %d squares, expected %d`, ErrInvalidRegions, region, size, g.dim)
+		}
+	}
+
+	boxes := g.units[2*g.dim:]
+	for i := range boxes {
+		boxes[i] = boxes[i][:0]
+	}
+	for idx := range g.points {
+		pt := &g.points[idx]
+		pt.box = regions[pt.row][pt.col]
+		boxes[pt.box] = append(boxes[pt.box], idx)
+	}
+	return nil
+}
+
+func (g *geometry) point(r, c int) point {
+	return g.points[r*g.dim+c]
+}
+
+// digitSet is a set of entries where bit n is set if n is in the set.
+type digitSet uint32
+
+func digitBit(n int) digitSet {
+	return 1 << uint(n)
+}
+
+func (ds digitSet) has(n int) bool {
+	return ds&digitBit(n) != 0
+}
+
+func (ds digitSet) len() int {
+	return bits.OnesCount32(uint32(ds))
+}
+
+// digits returns the numbers in the set in increasing order.
+func (ds digitSet) digits() []int {
+	var res []int
+	for ds != 0 {
+		n := bits.TrailingZeros32(uint32(ds))
+		res = append(res, n)
+		ds &^= digitBit(n)
+	}
+	return res
+}
+
+// cells returns the squares of every row, column, and box as cells.
+func (g *geometry) cells() [][]Cell {
+	res := make([][]Cell, len(g.units))
+	for i, unit := range g.units {
+		res[i] = make([]Cell, len(unit))
+		for j, idx := range unit {
+			res[i][j] = Cell{Row: g.points[idx].row, Col: g.points[idx].col}
+		}
 	}
+	return res
 }
 
+// diagonals returns the squares of the main diagonal, from top left to bottom right, and of the
+// anti-diagonal, from top right to bottom left.
+func (g *geometry) diagonals() [][]Cell {
+	res := [][]Cell{make([]Cell, g.dim), make([]Cell, g.dim)}
+	for i := 0; i < g.dim; i++ {
+		res[0][i] = Cell{Row: i, Col: i}
+		res[1][i] = Cell{Row: i, Col: g.dim - 1 - i}
+	}
+	return res
+}
+
+// puzzleCache holds the constraints the board has to satisfy and keeps them up to date as numbers
+// are written and cleared.
 type puzzleCache struct {
-	rows  []pointCache
-	cols  []pointCache
-	boxes []pointCache
+	g *geometry
+	// classic enforces the classic rules. It's kept apart from the other constraints so the search
+	// can call it directly.
+	classic *unitConstraint
+	extra   []Constraint
+
+	// masked and unmasked split up the extra constraints, so that finding candidates doesn't need to
+	// check which kind each constraint is.
+	masked   []maskedConstraint
+	unmasked []Constraint
 }
 
-func newPuzzleCache() *puzzleCache {
+// newPuzzleCache returns a cache enforcing the classic rules for the geometry along with any extra
+// constraints.
+func newPuzzleCache(g *geometry, extra ...Constraint) *puzzleCache {
 	pc := &puzzleCache{
-		rows:  make([]pointCache, Dimension),
-		cols:  make([]pointCache, Dimension),
-		boxes: make([]pointCache, Dimension),
+		g:       g,
+		classic: newUnitConstraint(g.cells(), duplicateNumber),
+		extra:   extra,
 	}
-	for i := 0; i < Dimension; i++ {
-		pc.rows[i] = make(pointCache)
-		pc.cols[i] = make(pointCache)
-		pc.boxes[i] = make(pointCache)
+	for _, con := range extra {
+		if mc, ok := con.(maskedConstraint); ok {
+			pc.masked = append(pc.masked, mc)
+		} else {
+			pc.unmasked = append(pc.unmasked, con)
+		}
 	}
 	return pc
 }
 
+// clone returns an independent copy of the cache, including the numbers placed so far.
+func (pc *puzzleCache) clone() *puzzleCache {
+	extra := make([]Constraint, len(pc.extra))
+	for i, con := range pc.extra {
+		extra[i] = con.Clone()
+	}
+	res := newPuzzleCache(pc.g, extra...)
+	res.classic = pc.classic.Clone().(*unitConstraint)
+	return res
+}
+
+func (pc *puzzleCache) constraints() []Constraint {
+	return append([]Constraint{pc.classic}, pc.extra...)
+}
+
 func (pc *puzzleCache) add(r, c, n int) {
 	if n == 0 {
 		return
 	}
-	pt := newPoint(r, c)
-	pc.rows[pt.row].add(pt, n)
-	pc.cols[pt.col].add(pt, n)
-	pc.boxes[pt.box].add(pt, n)
+	pc.classic.Place(r, c, n)
+	for _, con := range pc.extra {
+		con.Place(r, c, n)
+	}
 }
 
 func (pc *puzzleCache) remove(r, c, n int) {
-	pt := newPoint(r, c)
-	pc.rows[pt.row].remove(pt, n)
-	pc.cols[pt.col].remove(pt, n)
-	pc.boxes[pt.box].remove(pt, n)
+	pc.classic.Clear(r, c, n)
+	for _, con := range pc.extra {
+		con.Clear(r, c, n)
+	}
 }
 
 func (pc *puzzleCache) isValidEntry(r, c, n int) bool {
-	pt := newPoint(r, c)
-	return pc.rows[pt.row].isValidEntry(n) &&
-		pc.cols[pt.col].isValidEntry(n) &&
-		pc.boxes[pt.box].isValidEntry(n)
-}
-
-func (pc *puzzleCache) validateDuplicates() []*InvalidSquareError {
-	var errSet map[point]*InvalidSquareError
-	for i := 0; i < Dimension; i++ {
-		for _, err := range pc.rows[i].getInvalidEntries() {
-			if errSet == nil {
-				errSet = make(map[point]*InvalidSquareError)
-			}
-			errSet[newPoint(err.Row, err.Col)] = err
-		}
-		for _, err := range pc.cols[i].getInvalidEntries() {
-			if errSet == nil {
-				errSet = make(map[point]*InvalidSquareError)
-			}
-			errSet[newPoint(err.Row, err.Col)] = err
-		}
-		for _, err := range pc.boxes[i].getInvalidEntries() {
-			if errSet == nil {
-				errSet = make(map[point]*InvalidSquareError)
-			}
-			errSet[newPoint(err.Row, err.Col)] = err
-		}
+	if !pc.classic.Allows(r, c, n) {
+		return false
 	}
-	errs := make([]*InvalidSquareError, 0, len(errSet))
-	for _, err := range errSet {
-		errs = append(errs, err)
+	for _, con := range pc.extra {
+		if !con.Allows(r, c, n) {
+			return false
+		}
 	}
-	return errs
+	return true
 }
 
-type pointCache map[int]map[point]struct{}
-
-func (pc pointCache) add(pt point, n int) {
-	ptSet, ok := pc[n]
-	if !ok {
-		ptSet = make(map[point]struct{})
-		pc[n] = ptSet
+// candidates returns the entries that every constraint allows at the square.
+func (pc *puzzleCache) candidates(r, c int) digitSet {
+	cands := pc.classic.filter(r, c, pc.g.allDigits)
+	for _, con := range pc.masked {
+		cands = con.filter(r, c, cands)
 	}
-	ptSet[pt] = struct{}{}
-}
-
-func (pc pointCache) remove(pt point, n int) {
-	delete(pc[n], pt)
-}
-
-func (pc pointCache) isValidEntry(n int) bool {
-	return len(pc[n]) == 0
+	if len(pc.unmasked) == 0 {
+		return cands
+	}
+	for n := MinEntry; n <= pc.g.dim; n++ {
+		if !cands.has(n) {
+			continue
+		}
+		for _, con := range pc.unmasked {
+			if !con.Allows(r, c, n) {
+				cands &^= digitBit(n)
+				break
+			}
+		}
+	}
+	return cands
 }
 
-func (pc pointCache) getInvalidEntries() []*InvalidSquareError {
+// validateDuplicates reports every square of the board that breaks a constraint. A square breaking
+// several constraints is reported once, for the first of them.
+func (pc *puzzleCache) validateDuplicates(board *Board) []*InvalidSquareError {
 	var errs []*InvalidSquareError
-	for _, pts := range pc {
-		if len(pts) <= 1 {
-			// valid
-			continue
-		}
-		for pt := range pts {
-			errs = append(errs, newInvalidSquareError(pt.row, pt.col, duplicateNumber))
+	reported := make(map[Cell]bool)
+	for _, con := range pc.constraints() {
+		for _, err := range con.Violations(*board) {
+			cell := Cell{Row: err.Row, Col: err.Col}
+			if reported[cell] {
+				continue
+			}
+			reported[cell] = true
+			errs = append(errs, err)
 		}
 	}
 	return errs
@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestNewPoint(t *testing.T) {
+func TestGeometryPoint(t *testing.T) {
 	tests := []struct {
 		row      int
 		col      int
@@ -58,10 +58,32 @@ func TestNewPoint(t *testing.T) {
 		expBox:   8,
 		expIndex: 78,
 	}}
+	g := newGeometry(3, 3)
 	for _, tc := range tests {
-		pt := newPoint(tc.row, tc.col)
+		pt := g.point(tc.row, tc.col)
 		require.Equal(t, tc.row, pt.row)
 		require.Equal(t, tc.col, pt.col)
 		require.Equal(t, tc.expBox, pt.box)
 	}
 }
+
+func TestGeometryNonSquareBoxes(t *testing.T) {
+	// 6x6 boards have boxes two rows tall and three columns wide
+	g := newGeometry(2, 3)
+	expBoxes := [][]int{
+		{0, 0, 0, 1, 1, 1},
+		{0, 0, 0, 1, 1, 1},
+		{2, 2, 2, 3, 3, 3},
+		{2, 2, 2, 3, 3, 3},
+		{4, 4, 4, 5, 5, 5},
+		{4, 4, 4, 5, 5, 5},
+	}
+	for r, row := range expBoxes {
+		for c, box := range row {
+			require.Equal(t, box, g.point(r, c).box)
+		}
+	}
+	require.Len(t, g.units, 18)
+	require.Equal(t, []int{0, 1, 2, 6, 7, 8}, g.units[12])
+	require.Equal(t, digitSet(0x7e), g.allDigits)
+}
@@ -0,0 +1,127 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func jigsawRegions() [][]int {
+	return [][]int{
+		{0, 0, 0, 1, 1, 1, 2, 2, 2},
+		{0, 0, 0, 0, 1, 1, 2, 2, 2},
+		{0, 3, 1, 1, 1, 1, 2, 2, 5},
+		{0, 3, 3, 4, 4, 4, 2, 5, 5},
+		{3, 3, 3, 4, 4, 4, 4, 5, 5},
+		{3, 3, 3, 4, 4, 5, 5, 5, 8},
+		{6, 7, 7, 7, 7, 7, 5, 8, 8},
+		{6, 6, 7, 6, 7, 7, 8, 8, 8},
+		{6, 6, 6, 6, 6, 7, 8, 8, 8},
+	}
+}
+
+func TestSolveRegions(t *testing.T) {
+	input := [][]int{
+		{1, 0, 3, 0, 0, 0, 7, 0, 0},
+		{0, 7, 0, 0, 0, 0, 0, 0, 0},
+		{4, 5, 8, 0, 0, 0, 0, 0, 0},
+		{0, 4, 0, 0, 0, 0, 0, 0, 0},
+		{0, 8, 1, 0, 0, 3, 0, 0, 0},
+		{0, 0, 0, 2, 6, 0, 8, 0, 0},
+		{2, 0, 5, 0, 0, 1, 0, 0, 0},
+		{0, 0, 6, 0, 0, 0, 0, 0, 3},
+		{0, 0, 0, 0, 3, 0, 2, 0, 0},
+	}
+	solved := [][]int{
+		{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{8, 7, 9, 6, 1, 2, 3, 4, 5},
+		{4, 5, 8, 3, 9, 7, 1, 2, 6},
+		{5, 4, 2, 1, 8, 9, 6, 3, 7},
+		{6, 8, 1, 7, 4, 3, 5, 9, 2},
+		{3, 9, 7, 2, 6, 5, 8, 1, 4},
+		{2, 3, 5, 9, 7, 1, 4, 6, 8},
+		{7, 1, 6, 8, 2, 4, 9, 5, 3},
+		{9, 6, 4, 5, 3, 8, 2, 7, 1},
+	}
+
+	s, err := newSolver(input, solver.WithRegions(jigsawRegions()), solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+
+	d, err := solver.NewDLX(mustBoard(t, input), solver.WithRegions(jigsawRegions()))
+	require.NoError(t, err)
+	actual, err = d.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+}
+
+func TestRegionsDuplicates(t *testing.T) {
+	// (0, 3) and (2, 2) share a region but not a row, column, or box
+	input := solver.NewEmptyBoard()
+	input.Set(0, 3, 5)
+	input.Set(2, 2, 5)
+
+	_, err := solver.New(input)
+	require.NoError(t, err)
+
+	_, err = solver.New(input, solver.WithRegions(jigsawRegions()))
+	require.IsType(t, &solver.InvalidBoardError{}, err)
+	require.ElementsMatch(t, []*solver.InvalidSquareError{{
+		Row: 0,
+		Col: 3,
+		Msg: `duplicate number in row, column, or box`,
+	}, {
+		Row: 2,
+		Col: 2,
+		Msg: `duplicate number in row, column, or box`,
+	}}, err.(*solver.InvalidBoardError).InvalidSquares)
+}
+
+func TestInvalidRegions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		regions func() [][]int
+		expMsg  string
+	}{{
+		desc:    `wrong number of rows`,
+		regions: func() [][]int { return jigsawRegions()[1:] },
+		expMsg:  `invalid regions: expected 9 rows`,
+	}, {
+		desc: `wrong number of cols`,
+		regions: func() [][]int {
+			rs := jigsawRegions()
+			rs[4] = rs[4][:8]
+			return rs
+		},
+		expMsg: `invalid regions: expected 9 cols`,
+	}, {
+		desc: `region out of range`,
+		regions: func() [][]int {
+			rs := jigsawRegions()
+			rs[8][8] = 9
+			return rs
+		},
+		expMsg: `invalid regions: region 9 at (8, 8) is out of range`,
+	}, {
+		desc: `uneven regions`,
+		regions: func() [][]int {
+			rs := jigsawRegions()
+			rs[0][3] = 0
+			return rs
+		},
+		expMsg: `invalid regions: region 0 has 10 squares, expected 9`,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := solver.New(solver.NewEmptyBoard(), solver.WithRegions(tc.regions()))
+			require.ErrorIs(t, err, solver.ErrInvalidRegions)
+			require.EqualError(t, err, tc.expMsg)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestSolveBoxSizes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		boxRows int
+		boxCols int
+		input   solver.Board
+	}{{
+		desc:    `4x4`,
+		boxRows: 2,
+		boxCols: 2,
+		input: mustBoard(t, [][]int{
+			{0, 0, 0, 3},
+			{0, 4, 0, 0},
+			{0, 0, 3, 0},
+			{2, 0, 0, 0},
+		}),
+	}, {
+		desc:    `6x6`,
+		boxRows: 2,
+		boxCols: 3,
+		input: mustBoard(t, [][]int{
+			{0, 0, 3, 0, 1, 0},
+			{5, 6, 0, 3, 2, 0},
+			{0, 5, 4, 2, 0, 3},
+			{2, 0, 6, 4, 5, 0},
+			{0, 1, 2, 0, 4, 5},
+			{0, 4, 0, 1, 0, 0},
+		}),
+	}, {
+		desc:    `empty 16x16`,
+		boxRows: 4,
+		boxCols: 4,
+		input:   solver.NewEmptyBoard(solver.WithBoxSize(4, 4)),
+	}, {
+		desc:    `half-filled 25x25`,
+		boxRows: 5,
+		boxCols: 5,
+		input:   checkeredBoard(5, 5),
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := solver.New(
+				tc.input,
+				solver.WithBoxSize(tc.boxRows, tc.boxCols),
+				solver.WithStrategy(solver.MostConstrained),
+			)
+			require.NoError(t, err)
+			actual, err := s.Solve()
+			require.NoError(t, err)
+			requireSolution(t, tc.boxRows, tc.boxCols, tc.input, actual)
+
+			d, err := solver.NewDLX(tc.input, solver.WithBoxSize(tc.boxRows, tc.boxCols))
+			require.NoError(t, err)
+			actual, err = d.Solve()
+			require.NoError(t, err)
+			requireSolution(t, tc.boxRows, tc.boxCols, tc.input, actual)
+		})
+	}
+}
+
+func TestNewBoxSizeErrors(t *testing.T) {
+	_, err := solver.New(solver.NewEmptyBoard(), solver.WithBoxSize(2, 2))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfRows)
+	require.EqualError(t, err, `expected 4 rows`)
+
+	_, err = newSolver([][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0}, {0, 0, 0, 0}}, solver.WithBoxSize(2, 2))
+	require.ErrorIs(t, err, solver.ErrWrongNumberOfCols)
+	require.EqualError(t, err, `expected 4 cols`)
+
+	_, err = newSolver([][]int{{0, 0, 0, 0}, {0, 5, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}}, solver.WithBoxSize(2, 2))
+	require.Equal(t, &solver.InvalidBoardError{
+		InvalidSquares: []*solver.InvalidSquareError{{
+			Row: 1,
+			Col: 1,
+			Msg: `number out of range`,
+		}},
+	}, err)
+
+	for _, size := range [][2]int{{0, 3}, {3, -1}, {5, 6}} {
+		_, err = solver.New(solver.NewEmptyBoard(), solver.WithBoxSize(size[0], size[1]))
+		require.Equal(t, solver.ErrInvalidBoxSize, err)
+		require.Equal(t, solver.Board{}, solver.NewEmptyBoard(solver.WithBoxSize(size[0], size[1])))
+	}
+}
+
+// checkeredBoard returns a valid board with every other square filled in.
+func checkeredBoard(boxRows, boxCols int) solver.Board {
+	dim := boxRows * boxCols
+	res := solver.NewEmptyBoard(solver.WithBoxSize(boxRows, boxCols))
+	for r := 0; r < dim; r++ {
+		for c := 0; c < dim; c++ {
+			if (r+c)%2 == 0 {
+				res.Set(r, c, (boxCols*(r%boxRows)+r/boxRows+c)%dim+1)
+			}
+		}
+	}
+	return res
+}
+
+// requireSolution checks that actual is a complete, valid board that keeps every number of input.
+func requireSolution(t *testing.T, boxRows, boxCols int, input, actual solver.Board) {
+	dim := boxRows * boxCols
+	require.Equal(t, dim, actual.Size())
+
+	rows := make([]map[int]bool, dim)
+	cols := make([]map[int]bool, dim)
+	boxes := make([]map[int]bool, dim)
+	for i := 0; i < dim; i++ {
+		rows[i], cols[i], boxes[i] = map[int]bool{}, map[int]bool{}, map[int]bool{}
+	}
+	for r := 0; r < dim; r++ {
+		for c := 0; c < dim; c++ {
+			n := actual.Get(r, c)
+			if input.Get(r, c) != solver.Empty {
+				require.Equal(t, input.Get(r, c), n)
+			}
+			require.True(t, n >= solver.MinEntry && n <= dim)
+
+			box := boxRows*(r/boxRows) + c/boxCols
+			require.False(t, rows[r][n] || cols[c][n] || boxes[box][n], `duplicate %d at (%d, %d)`, n, r, c)
+			rows[r][n], cols[c][n], boxes[box][n] = true, true, true
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package solver
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// cancelCheckInterval is how many guesses the search makes between checks of its context.
+const cancelCheckInterval = 1024
+
+// Solutions enumerates the solutions of a board one at a time. The search runs directly on the
+// solver's board, so the solver shouldn't be used for anything else until the enumeration is
+// exhausted or stopped.
+type Solutions struct {
+	s       *Solver
+	ctx     context.Context
+	stack   []guess
+	started bool
+	done    bool
+
+	// sharedNodes, if set, counts the guesses of every search sharing the solver's node budget. It's
+	// updated every cancelCheckInterval guesses rather than on every guess.
+	sharedNodes *int64
+	err         error
+}
+
+type guess struct {
+	idx int
+	n   int
+}
+
+// Solutions returns an enumeration of the board's solutions, which Next produces one at a time as
+// the search finds them. The search runs on the solver's own board, which is back in its original
+// state once the enumeration runs out of solutions or Stop ends it early.
+func (s *Solver) Solutions() *Solutions {
+	return s.SolutionsContext(context.Background())
+}
+
+// SolutionsContext is like Solutions, but the enumeration ends with ErrCanceled once ctx is done.
+func (s *Solver) SolutionsContext(ctx context.Context) *Solutions {
+	s.resetStats()
+	return &Solutions{
+		s:     s,
+		ctx:   ctx,
+		stack: make([]guess, 0, len(s.nums)),
+	}
+}
+
+// Err returns the error that ended the enumeration early, or nil if it ran out of solutions or was
+// stopped. It's ErrCanceled once the context is done, or ErrBudgetExceeded once the search makes
+// more guesses than the solver's node budget allows. The solver is back in its original state
+// either way.
+func (sols *Solutions) Err() error {
+	return sols.err
+}
+
+// Next resumes the search and returns the next solution, or false once there are no more.
+func (sols *Solutions) Next() (Board, bool) {
+	if !sols.advance() {
+		return Board{}, false
+	}
+	return sols.s.ToBoard(), true
+}
+
+// Stop ends the enumeration early and undoes every guess, returning the solver to its original
+// state. Next always returns false after Stop.
+func (sols *Solutions) Stop() {
+	for len(sols.stack) > 0 {
+		sols.pop()
+	}
+	sols.done = true
+}
+
+// advance moves the search to the next completed grid and leaves it filled in on the solver. It
+// returns false once the search space is exhausted.
+func (sols *Solutions) advance() bool {
+	if sols.done {
+		return false
+	}
+	stats := &sols.s.stats
+	start := time.Now()
+	defer func() {
+		stats.Elapsed += time.Since(start)
+	}()
+	if err := sols.ctx.Err(); err != nil {
+		return sols.fail(canceledError{cause: err})
+	}
+	if !sols.started {
+		sols.started = true
+		if !sols.descend(0) {
+			// there's nothing to fill in, so the board is its own only solution
+			sols.solved()
+			return true
+		}
+	}
+	for len(sols.stack) > 0 {
+		top := &sols.stack[len(sols.stack)-1]
+		r, c := top.idx/sols.s.geo.dim, top.idx%sols.s.geo.dim
+		if top.n != Empty {
+			sols.s.clearAt(r, c, top.n)
+			if obs := sols.s.opts.observer; obs != nil {
+				obs.OnBacktrack(r, c, top.n)
+			}
+		}
+		top.n = sols.s.nextGuess(r, c, top.n)
+		if top.n == Empty {
+			// every guess here failed; backtrack to the previous square
+			sols.stack = sols.stack[:len(sols.stack)-1]
+			stats.Backtracks++
+			continue
+		}
+		sols.s.writeAt(r, c, top.n)
+		if obs := sols.s.opts.observer; obs != nil {
+			obs.OnPlace(r, c, top.n)
+		}
+		stats.Nodes++
+		stats.Guesses[r][c]++
+		budget := sols.s.opts.nodeBudget
+		if sols.sharedNodes == nil && budget > 0 && stats.Nodes > budget {
+			return sols.fail(ErrBudgetExceeded)
+		}
+		if stats.Nodes%cancelCheckInterval == 0 {
+			if sols.sharedNodes != nil && !sols.shareNodes(cancelCheckInterval) {
+				return false
+			}
+			if err := sols.ctx.Err(); err != nil {
+				return sols.fail(canceledError{cause: err})
+			}
+		}
+		if !sols.descend(top.idx + 1) {
+			sols.solved()
+			return true
+		}
+	}
+	sols.done = true
+	return false
+}
+
+// shareNodes adds n guesses to the count shared with the other searches, failing with
+// ErrBudgetExceeded if the total goes over the solver's node budget. It returns false if it fails.
+func (sols *Solutions) shareNodes(n int) bool {
+	total := atomic.AddInt64(sols.sharedNodes, int64(n))
+	if budget := sols.s.opts.nodeBudget; budget > 0 && total > int64(budget) {
+		return sols.fail(ErrBudgetExceeded)
+	}
+	return true
+}
+
+// fail ends the enumeration with err and undoes every guess. It always returns false.
+func (sols *Solutions) fail(err error) bool {
+	sols.Stop()
+	sols.err = err
+	return false
+}
+
+// descend pushes the next empty square to fill in onto the stack. Squares before start are known to
+// be filled in. It returns false if there is no empty square, meaning the grid is complete.
+func (sols *Solutions) descend(start int) bool {
+	idx := sols.s.nextSquare(start)
+	if idx < 0 {
+		return false
+	}
+	sols.stack = append(sols.stack, guess{idx: idx})
+	if len(sols.stack) > sols.s.stats.MaxDepth {
+		sols.s.stats.MaxDepth = len(sols.stack)
+	}
+	return true
+}
+
+func (sols *Solutions) pop() {
+	top := sols.stack[len(sols.stack)-1]
+	if top.n != Empty {
+		r, c := top.idx/sols.s.geo.dim, top.idx%sols.s.geo.dim
+		sols.s.clearAt(r, c, top.n)
+		if obs := sols.s.opts.observer; obs != nil {
+			obs.OnBacktrack(r, c, top.n)
+		}
+	}
+	sols.stack = sols.stack[:len(sols.stack)-1]
+}
+
+// solved tells the observer, if there is one, about the solution filled in on the solver.
+func (sols *Solutions) solved() {
+	if obs := sols.s.opts.observer; obs != nil {
+		obs.OnSolved(sols.s.ToBoard())
+	}
+}
+
+// nextSquare returns the empty square the strategy fills in next, or -1 if there are no empty
+// squares. Squares before start are known to be filled in.
+func (s *Solver) nextSquare(start int) int {
+	if s.opts.strategy == MostConstrained {
+		return s.mostConstrainedSquare()
+	}
+	return s.firstEmptySquare(start)
+}
+
+func (s *Solver) firstEmptySquare(start int) int {
+	for idx := start; idx < len(s.nums); idx++ {
+		if s.nums[idx] == Empty {
+			return idx
+		}
+	}
+	return -1
+}
+
+// mostConstrainedSquare returns the empty square with the fewest valid entries, or -1 if there are
+// no empty squares. It stops looking as soon as it finds a square with at most one valid entry.
+func (s *Solver) mostConstrainedSquare() int {
+	best, bestCount := -1, s.geo.dim+1
+	for idx, n := range s.nums {
+		if n != Empty {
+			continue
+		}
+		count := s.cache.candidates(idx/s.geo.dim, idx%s.geo.dim).len()
+		if count < bestCount {
+			best, bestCount = idx, count
+			if count <= 1 {
+				break
+			}
+		}
+	}
+	return best
+}
+
+// nextGuess returns the smallest valid entry for the square that is greater than after, or Empty if
+// there is none.
+func (s *Solver) nextGuess(r, c, after int) int {
+	cands := s.cache.candidates(r, c)
+	for n := after + 1; n <= s.geo.dim; n++ {
+		if cands.has(n) {
+			return n
+		}
+	}
+	return Empty
+}
@@ -0,0 +1,121 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func multiSolutionBoard() [][]int {
+	return [][]int{
+		{0, 0, 9, 0, 1, 6, 0, 4, 2},
+		{1, 0, 4, 2, 0, 9, 0, 6, 0},
+		{0, 2, 0, 0, 0, 8, 7, 0, 0},
+		{3, 5, 0, 0, 9, 0, 1, 0, 0},
+		{0, 6, 7, 4, 0, 1, 9, 0, 5},
+		{0, 0, 0, 7, 5, 0, 0, 8, 6},
+		{0, 9, 0, 0, 0, 4, 8, 5, 7},
+		{8, 0, 0, 9, 6, 0, 0, 2, 0},
+		{4, 7, 0, 8, 0, 5, 0, 0, 0},
+	}
+}
+
+// hardBoard returns a board with a unique solution that takes the solvers a lot of guessing.
+func hardBoard() [][]int {
+	return [][]int{
+		{4, 0, 0, 0, 0, 0, 8, 0, 5},
+		{0, 3, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 7, 0, 0, 0, 0, 0},
+		{0, 2, 0, 0, 0, 0, 0, 6, 0},
+		{0, 0, 0, 0, 8, 0, 4, 0, 0},
+		{0, 0, 0, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 6, 0, 3, 0, 7, 0},
+		{5, 0, 0, 2, 0, 0, 0, 0, 0},
+		{1, 0, 4, 0, 0, 0, 0, 0, 0},
+	}
+}
+
+func TestSolutions(t *testing.T) {
+	input := multiSolutionBoard()
+	s, err := newSolver(input)
+	require.NoError(t, err)
+
+	// the four solutions differ only in how two pairs of digits, 1/9 and 2/3, are arranged
+	expSolutions := make([]solver.Board, 0, 4)
+	for _, pairs := range [][4]int{{1, 9, 2, 3}, {1, 9, 3, 2}, {9, 1, 2, 3}, {9, 1, 3, 2}} {
+		expSolutions = append(expSolutions, mustBoard(t, [][]int{
+			{7, 8, 9, 5, 1, 6, 3, 4, 2},
+			{1, 3, 4, 2, 7, 9, 5, 6, 8},
+			{5, 2, 6, 3, 4, 8, 7, pairs[0], pairs[1]},
+			{3, 5, 8, 6, 9, 2, 1, 7, 4},
+			{2, 6, 7, 4, 8, 1, 9, 3, 5},
+			{9, 4, 1, 7, 5, 3, 2, 8, 6},
+			{6, 9, pairs[2], 1, pairs[3], 4, 8, 5, 7},
+			{8, 1, 5, 9, 6, 7, 4, 2, 3},
+			{4, 7, pairs[3], 8, pairs[2], 5, 6, pairs[1], pairs[0]},
+		}))
+	}
+
+	sols := s.Solutions()
+	var actual []solver.Board
+	for {
+		sol, ok := sols.Next()
+		if !ok {
+			break
+		}
+		actual = append(actual, sol)
+	}
+	require.ElementsMatch(t, expSolutions, actual)
+
+	_, ok := sols.Next()
+	require.False(t, ok)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+}
+
+func TestSolutionsStop(t *testing.T) {
+	input := multiSolutionBoard()
+	s, err := newSolver(input)
+	require.NoError(t, err)
+
+	sols := s.Solutions()
+	first, ok := sols.Next()
+	require.True(t, ok)
+
+	// the yielded board is a copy, so changing it must not affect the search
+	first.Set(0, 0, 0)
+
+	second, ok := sols.Next()
+	require.True(t, ok)
+	require.NotEqual(t, first, second)
+
+	sols.Stop()
+	_, ok = sols.Next()
+	require.False(t, ok)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+}
+
+func TestSolutionsCompleteBoard(t *testing.T) {
+	input := [][]int{
+		{7, 8, 9, 5, 1, 6, 3, 4, 2},
+		{1, 3, 4, 2, 7, 9, 5, 6, 8},
+		{5, 2, 6, 3, 4, 8, 7, 1, 9},
+		{3, 5, 8, 6, 9, 2, 1, 7, 4},
+		{2, 6, 7, 4, 8, 1, 9, 3, 5},
+		{9, 4, 1, 7, 5, 3, 2, 8, 6},
+		{6, 9, 2, 1, 3, 4, 8, 5, 7},
+		{8, 1, 5, 9, 6, 7, 4, 2, 3},
+		{4, 7, 3, 8, 2, 5, 6, 9, 1},
+	}
+	s, err := newSolver(input)
+	require.NoError(t, err)
+
+	sols := s.Solutions()
+	sol, ok := sols.Next()
+	require.True(t, ok)
+	require.Equal(t, mustBoard(t, input), sol)
+
+	_, ok = sols.Next()
+	require.False(t, ok)
+}
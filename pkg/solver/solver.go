@@ -1,6 +1,9 @@
 package solver
 
-import "errors"
+import (
+	"context"
+	"errors"
+)
 
 const (
 	Dimension    = 9
@@ -8,111 +11,213 @@ const (
 	Empty        = 0
 	MinEntry     = 1
 	MaxEntry     = 9
+
+	// MaxDimension is the largest number of rows and columns a board can have.
+	MaxDimension = 25
 )
 
 var (
 	ErrWrongNumberOfRows = errors.New(`expected 9 rows`)
 	ErrWrongNumberOfCols = errors.New(`expected 9 cols`)
+	ErrInvalidBoxSize    = errors.New(`box dimensions must be positive and span at most 25 squares`)
+	ErrInvalidRegions    = errors.New(`invalid regions`)
 	ErrNoSolution        = errors.New(`no solution exists for the given board`)
+	ErrMultipleSolutions = errors.New(`the board has more than one solution`)
+	ErrNeedsGuessing     = errors.New(`no known technique makes progress without guessing`)
+	ErrInconsistentBoard = errors.New(`the numbers on the board don't lead to a solution`)
+	ErrAlreadySolved     = errors.New(`the board is already solved`)
+	ErrCanceled          = errors.New(`solving was canceled`)
+	ErrBudgetExceeded    = errors.New(`solving took more guesses than the budget allows`)
+	ErrInvalidEncoding   = errors.New(`invalid binary board encoding`)
 )
 
-func NewEmptyBoard() [][]int {
-	res := make([][]int, 9)
-	for i := range res {
-		res[i] = make([]int, 9)
+// NewEmptyBoard returns a board with no numbers on it, sized for the box size in opts. It returns
+// the zero Board if the box size is invalid.
+func NewEmptyBoard(opts ...Option) Board {
+	o := newOptions(opts)
+	if !o.validBoxSize() {
+		return Board{}
 	}
-	return res
+	return Board{dim: o.boxRows * o.boxCols}
 }
 
 type Solver struct {
-	nums  [TotalSquares]int
+	nums  []int
+	geo   *geometry
 	cache *puzzleCache
+	opts  options
+	stats Stats
 }
 
-func New(board [][]int) (*Solver, error) {
-	if len(board) != Dimension {
-		return nil, ErrWrongNumberOfRows
+// New returns a solver for the board. The solver works on its own copy of the board.
+func New(board Board, opts ...Option) (*Solver, error) {
+	o := newOptions(opts)
+	if !o.validBoxSize() {
+		return nil, ErrInvalidBoxSize
 	}
-	s := &Solver{
-		cache: newPuzzleCache(),
+	geo := newGeometry(o.boxRows, o.boxCols)
+	if o.regions != nil {
+		if err := geo.setRegions(o.regions); err != nil {
+			return nil, err
+		}
 	}
-	var errs []*InvalidSquareError
-	for i, r := range board {
-		if len(r) != Dimension {
-			return nil, ErrWrongNumberOfCols
+	if board.dim != geo.dim {
+		return nil, &SizeError{
+			Expected: geo.dim,
+			Err:      ErrWrongNumberOfRows,
 		}
-		for j, n := range r {
-			if n < Empty || n > MaxEntry {
-				errs = append(errs, newInvalidSquareError(i, j, outOfRange))
-				continue
-			}
-			if n == 0 {
-				continue
-			}
+	}
+	extra := o.constraints
+	if o.diagonals {
+		extra = append(extra[:len(extra):len(extra)], newUnitConstraint(geo.diagonals(), duplicateInDiagonal))
+	}
+	var cageErrs []*InvalidCageError
+	var cages *cageConstraint
+	if len(o.cages) != 0 {
+		cageErrs = validateCages(geo, o.cages)
+		if len(cageErrs) == 0 {
+			cages = newCageConstraint(geo, o.cages)
+			extra = append(extra[:len(extra):len(extra)], cages)
+		}
+	}
+	s := &Solver{
+		nums:  make([]int, geo.dim*geo.dim),
+		geo:   geo,
+		cache: newPuzzleCache(geo, extra...),
+		opts:  o,
+	}
+	for idx, n := range board.cells[:len(s.nums)] {
+		if n != Empty {
 			// write without regard to duplicates; we'll validate those later
-			s.writeAt(i, j, n)
+			s.writeAt(idx/geo.dim, idx%geo.dim, int(n))
 		}
 	}
 
-	errs = append(errs, s.cache.validateDuplicates()...)
-	if len(errs) != 0 {
+	errs := s.cache.validateDuplicates(&board)
+	if cages != nil {
+		cageErrs = cages.sumViolations(&board)
+	}
+	if len(errs) != 0 || len(cageErrs) != 0 {
 		return nil, &InvalidBoardError{
 			InvalidSquares: errs,
+			InvalidCages:   cageErrs,
 		}
 	}
 	return s, nil
 }
 
-func (s *Solver) ToBoard() [][]int {
-	res := make([][]int, Dimension)
-	for i := 0; i < Dimension; i++ {
-		res[i] = s.nums[i*9 : (i*9)+9]
+// ToBoard returns a copy of the solver's board.
+func (s *Solver) ToBoard() Board {
+	b := Board{dim: s.geo.dim}
+	for idx, n := range s.nums {
+		b.cells[idx] = uint8(n)
 	}
-	return res
+	return b
 }
 
-func (s *Solver) Solve() ([][]int, error) {
-	if err := s.solveFrom(0); err != nil {
-		return nil, err
+// Solve returns a solution of the board. The solver is left in its original state, so it can be
+// used again.
+func (s *Solver) Solve() (Board, error) {
+	return s.SolveContext(context.Background())
+}
+
+// SolveContext is like Solve, but gives up with ErrCanceled once ctx is done. Both give up with
+// ErrBudgetExceeded if the search makes more guesses than WithNodeBudget allows.
+func (s *Solver) SolveContext(ctx context.Context) (Board, error) {
+	if s.opts.workers > 1 {
+		return s.solveParallel(ctx)
+	}
+	sols := s.SolutionsContext(ctx)
+	defer sols.Stop()
+	if !sols.advance() {
+		if err := sols.Err(); err != nil {
+			return Board{}, err
+		}
+		return Board{}, ErrNoSolution
 	}
 	return s.ToBoard(), nil
 }
 
-func (s *Solver) solveFrom(start int) error {
-	if start >= len(s.nums) {
-		return nil
+// CountSolutions counts the solutions of the board, stopping once limit solutions have been found.
+// A limit of zero or less counts every solution. The solver is left in its original state. If the
+// search runs out of its node budget, the count is only a lower bound; CountSolutionsContext
+// reports when that happens.
+func (s *Solver) CountSolutions(limit int) int {
+	count, _ := s.CountSolutionsContext(context.Background(), limit)
+	return count
+}
+
+// CountSolutionsContext is like CountSolutions, but gives up with ErrCanceled once ctx is done or
+// with ErrBudgetExceeded once the search runs out of its node budget. It returns the solutions
+// counted so far along with the error.
+func (s *Solver) CountSolutionsContext(ctx context.Context, limit int) (int, error) {
+	if s.opts.workers > 1 {
+		count, _, err := s.searchParallel(ctx, limit)
+		return count, err
 	}
-	for i, n := range s.nums[start:] {
-		if n != 0 {
-			// there's already a number here
-			continue
-		}
-		for guess := MinEntry; guess <= MaxEntry; guess++ {
-			idx := start + i
-			r, c := idx/9, idx%9
-			if !s.cache.isValidEntry(r, c, guess) {
-				continue
-			}
-			s.writeAt(r, c, guess)
-			if err := s.solveFrom(start + i + 1); err == ErrNoSolution {
-				s.clearAt(r, c, guess)
-				continue
-			} else if err != nil {
-				return err
-			}
-			return nil
+	sols := s.SolutionsContext(ctx)
+	defer sols.Stop()
+
+	count := 0
+	for limit <= 0 || count < limit {
+		if !sols.advance() {
+			break
 		}
-		return ErrNoSolution
+		count++
+	}
+	return count, sols.Err()
+}
+
+// IsUnique reports whether the board has exactly one solution.
+func (s *Solver) IsUnique() bool {
+	return s.CountSolutions(2) == 1
+}
+
+// IsUniqueContext is like IsUnique, but gives up with the same errors as CountSolutionsContext.
+func (s *Solver) IsUniqueContext(ctx context.Context) (bool, error) {
+	count, err := s.CountSolutionsContext(ctx, 2)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+// newUniqueSolver returns a solver for the board after checking that it has exactly one solution,
+// failing with noSolution if it has none. The search defaults to MostConstrained, since counting
+// solutions is much faster when forced moves are filled in first.
+func newUniqueSolver(ctx context.Context, board Board, noSolution error, opts []Option) (*Solver, error) {
+	opts = append([]Option{WithStrategy(MostConstrained)}, opts...)
+	s, err := New(board, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUnique(ctx, noSolution); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkUnique fails unless the board has exactly one solution: with noSolution if it has none, or
+// with ErrMultipleSolutions. It also fails if the search gives up or ctx is done.
+func (s *Solver) checkUnique(ctx context.Context, noSolution error) error {
+	count, err := s.CountSolutionsContext(ctx, 2)
+	switch {
+	case err != nil:
+		return err
+	case count == 0:
+		return noSolution
+	case count > 1:
+		return ErrMultipleSolutions
 	}
 	return nil
 }
 
 func (s *Solver) writeAt(r, c, n int) {
-	s.nums[r*9+c] = n
+	s.nums[r*s.geo.dim+c] = n
 	s.cache.add(r, c, n)
 }
 
 func (s *Solver) clearAt(r, c, n int) {
-	s.nums[r*9+c] = 0
+	s.nums[r*s.geo.dim+c] = 0
 	s.cache.remove(r, c, n)
 }
@@ -31,12 +31,18 @@ func TestSolve(t *testing.T) {
 		{8, 1, 5, 9, 6, 7, 4, 2, 3},
 		{4, 7, 3, 8, 2, 5, 6, 9, 1},
 	}
-	s, err := solver.New(input)
+	s, err := newSolver(input)
 	require.NoError(t, err)
 
 	actual, err := s.Solve()
 	require.NoError(t, err)
-	require.Equal(t, solved, actual)
+	require.Equal(t, mustBoard(t, solved), actual)
+
+	// solving leaves the solver as it was, so it can solve again
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
+	again, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, actual, again)
 }
 
 func TestNoSolution(t *testing.T) {
@@ -51,12 +57,12 @@ func TestNoSolution(t *testing.T) {
 		{6, 8, 4, 2, 0, 7, 5, 0, 0},
 		{7, 9, 1, 0, 5, 0, 6, 0, 8},
 	}
-	s, err := solver.New(input)
+	s, err := newSolver(input)
 	require.NoError(t, err)
 
 	actual, err := s.Solve()
 	require.Equal(t, solver.ErrNoSolution, err)
-	require.Nil(t, actual)
+	require.Equal(t, solver.Board{}, actual)
 }
 
 func TestToBoard(t *testing.T) {
@@ -71,9 +77,14 @@ func TestToBoard(t *testing.T) {
 		{0, 0, 0, 0, 0, 0, 0, 8, 0},
 		{0, 0, 0, 0, 0, 0, 0, 0, 9},
 	}
-	s, err := solver.New(input)
+	s, err := newSolver(input)
 	require.NoError(t, err)
-	require.Equal(t, input, s.ToBoard())
+	board := s.ToBoard()
+	require.Equal(t, input, board.Rows())
+
+	// the board is a copy
+	board.Set(0, 0, 2)
+	require.Equal(t, mustBoard(t, input), s.ToBoard())
 }
 
 func TestNew(t *testing.T) {
@@ -332,14 +343,174 @@ func TestNew(t *testing.T) {
 	for _, tc := range tests {
 		tc := tc
 		t.Run(tc.desc, func(t *testing.T) {
-			_, err := solver.New(tc.input)
+			_, err := newSolver(tc.input)
 			switch exp := tc.expErr.(type) {
 			case *solver.InvalidBoardError:
 				require.IsType(t, &solver.InvalidBoardError{}, err)
 				require.ElementsMatch(t, exp.InvalidSquares, err.(*solver.InvalidBoardError).InvalidSquares)
+			case nil:
+				require.NoError(t, err)
 			default:
-				require.Equal(t, tc.expErr, err)
+				require.ErrorIs(t, err, tc.expErr)
 			}
 		})
 	}
 }
+
+func TestCountSolutions(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    [][]int
+		limit    int
+		expCount int
+	}{{
+		desc: `unique solution`,
+		input: [][]int{
+			{0, 0, 9, 0, 1, 6, 0, 4, 2},
+			{1, 0, 4, 2, 0, 9, 0, 6, 0},
+			{0, 2, 0, 0, 0, 8, 7, 1, 0},
+			{3, 5, 0, 0, 9, 0, 1, 0, 0},
+			{0, 6, 7, 4, 0, 1, 9, 0, 5},
+			{0, 0, 0, 7, 5, 0, 0, 8, 6},
+			{0, 9, 2, 0, 0, 4, 8, 5, 7},
+			{8, 0, 0, 9, 6, 0, 0, 2, 0},
+			{4, 7, 0, 8, 0, 5, 0, 0, 0},
+		},
+		limit:    0,
+		expCount: 1,
+	}, {
+		desc: `several solutions`,
+		input: [][]int{
+			{0, 0, 9, 0, 1, 6, 0, 4, 2},
+			{1, 0, 4, 2, 0, 9, 0, 6, 0},
+			{0, 2, 0, 0, 0, 8, 7, 0, 0},
+			{3, 5, 0, 0, 9, 0, 1, 0, 0},
+			{0, 6, 7, 4, 0, 1, 9, 0, 5},
+			{0, 0, 0, 7, 5, 0, 0, 8, 6},
+			{0, 9, 0, 0, 0, 4, 8, 5, 7},
+			{8, 0, 0, 9, 6, 0, 0, 2, 0},
+			{4, 7, 0, 8, 0, 5, 0, 0, 0},
+		},
+		limit:    0,
+		expCount: 4,
+	}, {
+		desc: `two solutions`,
+		input: [][]int{
+			{0, 8, 9, 5, 0, 6, 3, 4, 2},
+			{0, 3, 4, 2, 0, 9, 5, 6, 8},
+			{5, 2, 6, 3, 4, 8, 7, 1, 9},
+			{3, 5, 8, 6, 9, 2, 1, 7, 4},
+			{2, 6, 7, 4, 8, 1, 9, 3, 5},
+			{9, 4, 1, 7, 5, 3, 2, 8, 6},
+			{6, 9, 2, 1, 3, 4, 8, 5, 7},
+			{8, 1, 5, 9, 6, 7, 4, 2, 3},
+			{4, 7, 3, 8, 2, 5, 6, 9, 1},
+		},
+		limit:    0,
+		expCount: 2,
+	}, {
+		desc:     `stops at the limit`,
+		input:    solver.NewEmptyBoard().Rows(),
+		limit:    5,
+		expCount: 5,
+	}, {
+		desc: `no solution`,
+		input: [][]int{
+			{5, 1, 6, 8, 4, 9, 7, 3, 2},
+			{3, 0, 7, 6, 0, 5, 0, 0, 0},
+			{8, 0, 9, 7, 0, 0, 0, 6, 5},
+			{1, 3, 5, 0, 6, 0, 9, 0, 7},
+			{4, 7, 2, 5, 9, 1, 0, 0, 6},
+			{9, 6, 8, 3, 7, 0, 0, 5, 0},
+			{2, 5, 3, 1, 8, 6, 0, 7, 4},
+			{6, 8, 4, 2, 0, 7, 5, 0, 0},
+			{7, 9, 1, 0, 5, 0, 6, 0, 8},
+		},
+		limit:    0,
+		expCount: 0,
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := newSolver(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expCount, s.CountSolutions(tc.limit))
+			require.Equal(t, tc.expCount == 1, s.IsUnique())
+
+			// counting must not disturb the board
+			require.Equal(t, mustBoard(t, tc.input), s.ToBoard())
+		})
+	}
+}
+
+func TestSolveMostConstrained(t *testing.T) {
+	// this puzzle was designed against sequential backtracking, which needs seconds to solve it
+	input := [][]int{
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 3, 0, 8, 5},
+		{0, 0, 1, 0, 2, 0, 0, 0, 0},
+		{0, 0, 0, 5, 0, 7, 0, 0, 0},
+		{0, 0, 4, 0, 0, 0, 1, 0, 0},
+		{0, 9, 0, 0, 0, 0, 0, 0, 0},
+		{5, 0, 0, 0, 0, 0, 0, 7, 3},
+		{0, 0, 2, 0, 1, 0, 0, 0, 0},
+		{0, 0, 0, 0, 4, 0, 0, 0, 9},
+	}
+	solved := [][]int{
+		{9, 8, 7, 6, 5, 4, 3, 2, 1},
+		{2, 4, 6, 1, 7, 3, 9, 8, 5},
+		{3, 5, 1, 9, 2, 8, 7, 4, 6},
+		{1, 2, 8, 5, 3, 7, 6, 9, 4},
+		{6, 3, 4, 8, 9, 2, 1, 5, 7},
+		{7, 9, 5, 4, 6, 1, 8, 3, 2},
+		{5, 1, 9, 2, 8, 6, 4, 7, 3},
+		{4, 7, 2, 3, 1, 9, 5, 6, 8},
+		{8, 6, 3, 7, 4, 5, 2, 1, 9},
+	}
+	s, err := newSolver(input, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.True(t, s.IsUnique())
+
+	actual, err := s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, mustBoard(t, solved), actual)
+}
+
+func TestStrategiesAgree(t *testing.T) {
+	for _, st := range []solver.Strategy{solver.Sequential, solver.MostConstrained} {
+		s, err := newSolver(multiSolutionBoard(), solver.WithStrategy(st))
+		require.NoError(t, err)
+		require.Equal(t, 4, s.CountSolutions(0))
+
+		s, err = newSolver([][]int{
+			{5, 1, 6, 8, 4, 9, 7, 3, 2},
+			{3, 0, 7, 6, 0, 5, 0, 0, 0},
+			{8, 0, 9, 7, 0, 0, 0, 6, 5},
+			{1, 3, 5, 0, 6, 0, 9, 0, 7},
+			{4, 7, 2, 5, 9, 1, 0, 0, 6},
+			{9, 6, 8, 3, 7, 0, 0, 5, 0},
+			{2, 5, 3, 1, 8, 6, 0, 7, 4},
+			{6, 8, 4, 2, 0, 7, 5, 0, 0},
+			{7, 9, 1, 0, 5, 0, 6, 0, 8},
+		}, solver.WithStrategy(st))
+		require.NoError(t, err)
+		_, err = s.Solve()
+		require.Equal(t, solver.ErrNoSolution, err)
+	}
+}
+
+// newSolver converts rows into a board and makes a solver for it.
+func newSolver(rows [][]int, opts ...solver.Option) (*solver.Solver, error) {
+	board, err := solver.NewBoard(rows)
+	if err != nil {
+		return nil, err
+	}
+	return solver.New(board, opts...)
+}
+
+// mustBoard converts rows into a board, failing the test if they don't make one.
+func mustBoard(t testing.TB, rows [][]int) solver.Board {
+	board, err := solver.NewBoard(rows)
+	require.NoError(t, err)
+	return board
+}
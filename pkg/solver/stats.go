@@ -0,0 +1,62 @@
+package solver
+
+import "time"
+
+// Stats describes the work done by a search.
+type Stats struct {
+	// Nodes is the number of guesses the search wrote.
+	Nodes int `json:"nodes"`
+	// Backtracks is the number of times every guess at a square failed and the search went back to
+	// the square before it.
+	Backtracks int `json:"backtracks"`
+	// MaxDepth is the most guesses the search had on the board at once.
+	MaxDepth int `json:"maxDepth"`
+	// Guesses holds how many guesses the search wrote in each square.
+	Guesses [][]int `json:"guesses"`
+	// Elapsed is the time spent searching. It's encoded in JSON as nanoseconds.
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// Stats returns the statistics of the solver's most recent search, whether it solved the board,
+// counted its solutions, or enumerated them. It's the zero Stats if the solver hasn't searched yet.
+func (s *Solver) Stats() Stats {
+	res := s.stats
+	if res.Guesses != nil {
+		res.Guesses = newGrid(s.geo.dim)
+		for r, row := range s.stats.Guesses {
+			copy(res.Guesses[r], row)
+		}
+	}
+	return res
+}
+
+// resetStats clears the solver's statistics for a new search.
+func (s *Solver) resetStats() {
+	s.stats = Stats{
+		Guesses: newGrid(s.geo.dim),
+	}
+}
+
+// merge adds the statistics of a search that started with depth guesses already on the board.
+func (st *Stats) merge(other Stats, depth int) {
+	st.Nodes += other.Nodes
+	st.Backtracks += other.Backtracks
+	if d := depth + other.MaxDepth; d > st.MaxDepth {
+		st.MaxDepth = d
+	}
+	for r, row := range other.Guesses {
+		for c, n := range row {
+			st.Guesses[r][c] += n
+		}
+	}
+}
+
+// newGrid returns a dim by dim grid of zeros.
+func newGrid(dim int) [][]int {
+	flat := make([]int, dim*dim)
+	res := make([][]int, dim)
+	for i := range res {
+		res[i] = flat[i*dim : (i+1)*dim]
+	}
+	return res
+}
@@ -0,0 +1,97 @@
+package solver_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cszczepaniak/sudoku-solver/pkg/solver"
+)
+
+func TestStats(t *testing.T) {
+	easy := [][]int{
+		{0, 0, 9, 0, 1, 6, 0, 4, 2},
+		{1, 0, 4, 2, 0, 9, 0, 6, 0},
+		{0, 2, 0, 0, 0, 8, 7, 0, 0},
+		{3, 5, 0, 0, 9, 0, 1, 0, 0},
+		{0, 6, 7, 4, 0, 1, 9, 0, 5},
+		{0, 0, 0, 7, 5, 0, 0, 8, 6},
+		{0, 9, 0, 0, 0, 4, 8, 5, 7},
+		{8, 0, 0, 9, 6, 0, 0, 2, 0},
+		{4, 7, 0, 8, 0, 5, 0, 0, 0},
+	}
+	s, err := newSolver(easy, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.Equal(t, solver.Stats{}, s.Stats())
+
+	// every empty square of an easy board has a forced move, so nothing is ever undone
+	_, err = s.Solve()
+	require.NoError(t, err)
+	stats := s.Stats()
+	require.Equal(t, 41, stats.Nodes)
+	require.Equal(t, 0, stats.Backtracks)
+	require.Equal(t, 41, stats.MaxDepth)
+	require.Positive(t, stats.Elapsed)
+	for r, row := range easy {
+		for c, n := range row {
+			if n == solver.Empty {
+				require.Equal(t, 1, stats.Guesses[r][c])
+			} else {
+				require.Equal(t, 0, stats.Guesses[r][c])
+			}
+		}
+	}
+
+	// the returned stats are a copy
+	stats.Guesses[0][0] = 100
+	require.Equal(t, 1, s.Stats().Guesses[0][0])
+
+	// each search starts its stats afresh
+	_, err = s.Solve()
+	require.NoError(t, err)
+	require.Equal(t, 41, s.Stats().Nodes)
+
+	// a full board has nothing left to guess
+	full, err := s.Solve()
+	require.NoError(t, err)
+	s, err = solver.New(full)
+	require.NoError(t, err)
+	_, err = s.Solve()
+	require.NoError(t, err)
+	stats = s.Stats()
+	require.Equal(t, 0, stats.Nodes)
+	require.Equal(t, 0, stats.MaxDepth)
+}
+
+func TestStatsHardBoard(t *testing.T) {
+	hard := hardBoard()
+	s, err := newSolver(hard, solver.WithStrategy(solver.MostConstrained))
+	require.NoError(t, err)
+	require.Equal(t, 1, s.CountSolutions(0))
+	single := s.Stats()
+	require.Positive(t, single.Backtracks)
+	require.LessOrEqual(t, single.MaxDepth, 64)
+	requireGuessesAddUp(t, single)
+	require.Greater(t, single.Nodes, single.MaxDepth)
+
+	// the workers search the same tree between them, so their statistics add up to the same work
+	s, err = newSolver(hard, solver.WithStrategy(solver.MostConstrained), solver.WithParallelism(4))
+	require.NoError(t, err)
+	require.Equal(t, 1, s.CountSolutions(0))
+	parallel := s.Stats()
+	requireGuessesAddUp(t, parallel)
+	require.Equal(t, single.Nodes, parallel.Nodes)
+	require.Equal(t, single.MaxDepth, parallel.MaxDepth)
+	require.InDelta(t, single.Backtracks, parallel.Backtracks, float64(single.Backtracks)/10)
+}
+
+// requireGuessesAddUp checks that the guesses in each square add up to the number of nodes.
+func requireGuessesAddUp(t *testing.T, stats solver.Stats) {
+	total := 0
+	for _, row := range stats.Guesses {
+		for _, n := range row {
+			total += n
+		}
+	}
+	require.Equal(t, stats.Nodes, total)
+}
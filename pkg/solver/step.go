@@ -0,0 +1,103 @@
+package solver
+
+import "fmt"
+
+// Technique is a named deduction that a person can make about a board without guessing.
+// Techniques are ordered from easiest to hardest.
+type Technique int
+
+const (
+	// NakedSingle places the only candidate left in a square.
+	NakedSingle Technique = iota + 1
+	// HiddenSingle places a number in the only square of a unit that can hold it.
+	HiddenSingle
+	// PointingPair removes a number from a line when all of its candidates in a box lie on that
+	// line.
+	PointingPair
+	// BoxLineReduction removes a number from a box when all of its candidates on a line lie in
+	// that box.
+	BoxLineReduction
+	// NakedPair removes two numbers from a unit when they're the only candidates of two of its
+	// squares.
+	NakedPair
+	// HiddenPair removes the other candidates of two squares when they're the only squares of a
+	// unit that can hold two numbers.
+	HiddenPair
+	// NakedTriple is NakedPair for three squares and three numbers.
+	NakedTriple
+	// HiddenTriple is HiddenPair for three squares and three numbers.
+	HiddenTriple
+	// XWing removes a number from two columns when its candidates in two rows lie only in those
+	// columns, or the same with rows and columns swapped.
+	XWing
+	// Swordfish is XWing for three rows and three columns.
+	Swordfish
+	// XYWing removes a number from squares that see both pincers of a pivot with two candidates
+	// whose pincers each share one candidate with it and the number with each other.
+	XYWing
+)
+
+var techniqueNames = map[Technique]string{
+	NakedSingle:      `Naked Single`,
+	HiddenSingle:     `Hidden Single`,
+	PointingPair:     `Pointing Pair`,
+	BoxLineReduction: `Box/Line Reduction`,
+	NakedPair:        `Naked Pair`,
+	HiddenPair:       `Hidden Pair`,
+	NakedTriple:      `Naked Triple`,
+	HiddenTriple:     `Hidden Triple`,
+	XWing:            `X-Wing`,
+	Swordfish:        `Swordfish`,
+	XYWing:           `XY-Wing`,
+}
+
+func (t Technique) String() string {
+	if name, ok := techniqueNames[t]; ok {
+		return name
+	}
+	return `Unknown Technique`
+}
+
+// MarshalText encodes the technique as its name. The zero Technique, which a rating has when there
+// was nothing left to solve, is encoded as an empty name.
+func (t Technique) MarshalText() ([]byte, error) {
+	if t == 0 {
+		return []byte{}, nil
+	}
+	if _, ok := techniqueNames[t]; !ok {
+		return nil, fmt.Errorf(`unknown technique %d`, int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a technique from its name, or the zero Technique from an empty name.
+func (t *Technique) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = 0
+		return nil
+	}
+	for tech, name := range techniqueNames {
+		if name == string(text) {
+			*t = tech
+			return nil
+		}
+	}
+	return fmt.Errorf(`unknown technique %q`, text)
+}
+
+// Candidate pairs a square with a number. Steps use it both for numbers they place and for
+// candidates they eliminate.
+type Candidate struct {
+	Row   int `json:"row"`
+	Col   int `json:"col"`
+	Digit int `json:"digit"`
+}
+
+// Step is a single logical deduction. Cells are the squares whose candidates justify it, and it
+// either places numbers or eliminates candidates.
+type Step struct {
+	Technique    Technique   `json:"technique"`
+	Cells        []Cell      `json:"cells"`
+	Placements   []Candidate `json:"placements,omitempty"`
+	Eliminations []Candidate `json:"eliminations,omitempty"`
+}
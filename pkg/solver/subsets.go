@@ -0,0 +1,116 @@
+package solver
+
+import "math/bits"
+
+// nakedSubset finds k squares of a unit whose candidates are k numbers between them. Those numbers
+// have to go in those squares, so they're removed from the rest of the unit.
+func (ls *logicSolver) nakedSubset(t Technique, k int) (Step, bool) {
+	for _, unit := range ls.units {
+		var pool []int
+		for _, idx := range unit {
+			if l := ls.cands[idx].len(); l >= 2 && l <= k {
+				pool = append(pool, idx)
+			}
+		}
+		var step Step
+		found := eachCombination(len(pool), k, func(pick []int) bool {
+			var union digitSet
+			members := make([]int, k)
+			for i, p := range pick {
+				members[i] = pool[p]
+				union |= ls.cands[pool[p]]
+			}
+			if union.len() != k {
+				return false
+			}
+			var elims []Candidate
+			for _, idx := range unit {
+				if containsInt(members, idx) {
+					continue
+				}
+				for _, n := range (ls.cands[idx] & union).digits() {
+					elims = append(elims, ls.candidate(idx, n))
+				}
+			}
+			if len(elims) == 0 {
+				return false
+			}
+			step = Step{
+				Technique:    t,
+				Cells:        ls.cellsOf(members),
+				Eliminations: elims,
+			}
+			return true
+		})
+		if found {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// hiddenSubset finds k numbers that can only go in the same k squares of a unit. Those squares
+// have to hold those numbers, so their other candidates are removed.
+func (ls *logicSolver) hiddenSubset(t Technique, k int) (Step, bool) {
+	dim := ls.s.geo.dim
+	for _, unit := range ls.units {
+		// where holds the positions within the unit that can hold each number
+		where := make([]uint32, dim+1)
+		for i, idx := range unit {
+			for _, n := range ls.cands[idx].digits() {
+				where[n] |= 1 << uint(i)
+			}
+		}
+		var pool []int
+		for n := MinEntry; n <= dim; n++ {
+			if l := bits.OnesCount32(where[n]); l >= 2 && l <= k {
+				pool = append(pool, n)
+			}
+		}
+		var step Step
+		found := eachCombination(len(pool), k, func(pick []int) bool {
+			var span uint32
+			var nums digitSet
+			for _, p := range pick {
+				span |= where[pool[p]]
+				nums |= digitBit(pool[p])
+			}
+			if bits.OnesCount32(span) != k {
+				return false
+			}
+			var members []int
+			var elims []Candidate
+			for i, idx := range unit {
+				if span&(1<<uint(i)) == 0 {
+					continue
+				}
+				members = append(members, idx)
+				for _, n := range (ls.cands[idx] &^ nums).digits() {
+					elims = append(elims, ls.candidate(idx, n))
+				}
+			}
+			if len(elims) == 0 {
+				return false
+			}
+			step = Step{
+				Technique:    t,
+				Cells:        ls.cellsOf(members),
+				Eliminations: elims,
+			}
+			return true
+		})
+		if found {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+func containsInt(ns []int, n int) bool {
+	for _, m := range ns {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTechniques(t *testing.T) {
+	// set restricts the candidates of the square to nums
+	set := func(ls *logicSolver, r, c int, nums ...int) {
+		var cands digitSet
+		for _, n := range nums {
+			cands |= digitBit(n)
+		}
+		ls.cands[r*Dimension+c] = cands
+	}
+	// remove eliminates n from the squares of row r outside of the given columns
+	remove := func(ls *logicSolver, r int, n int, keep ...int) {
+		for c := 0; c < Dimension; c++ {
+			if !containsInt(keep, c) {
+				ls.cands[r*Dimension+c] &^= digitBit(n)
+			}
+		}
+	}
+	elims := func(n int, cells ...Cell) []Candidate {
+		res := make([]Candidate, len(cells))
+		for i, cell := range cells {
+			res[i] = Candidate{Row: cell.Row, Col: cell.Col, Digit: n}
+		}
+		return res
+	}
+	rowExcept := func(r int, keep ...int) []Cell {
+		var res []Cell
+		for c := 0; c < Dimension; c++ {
+			if !containsInt(keep, c) {
+				res = append(res, Cell{Row: r, Col: c})
+			}
+		}
+		return res
+	}
+	tests := []struct {
+		desc      string
+		setup     func(ls *logicSolver)
+		technique Technique
+		expStep   Step
+	}{{
+		desc: `naked single`,
+		setup: func(ls *logicSolver) {
+			set(ls, 4, 5, 7)
+		},
+		technique: NakedSingle,
+		expStep: Step{
+			Technique:  NakedSingle,
+			Cells:      []Cell{{Row: 4, Col: 5}},
+			Placements: []Candidate{{Row: 4, Col: 5, Digit: 7}},
+		},
+	}, {
+		desc: `hidden single`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 0, 7, 3)
+		},
+		technique: HiddenSingle,
+		expStep: Step{
+			Technique:  HiddenSingle,
+			Cells:      rowExcept(0),
+			Placements: []Candidate{{Row: 0, Col: 3, Digit: 7}},
+		},
+	}, {
+		desc: `pointing pair`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 1, 5, 3, 4, 5, 6, 7, 8)
+			remove(ls, 2, 5, 3, 4, 5, 6, 7, 8)
+		},
+		technique: PointingPair,
+		expStep: Step{
+			Technique:    PointingPair,
+			Cells:        []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}, {Row: 0, Col: 2}},
+			Eliminations: elims(5, rowExcept(0, 0, 1, 2)...),
+		},
+	}, {
+		desc: `box/line reduction`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 0, 5, 0, 1)
+		},
+		technique: BoxLineReduction,
+		expStep: Step{
+			Technique: BoxLineReduction,
+			Cells:     []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 1}},
+			Eliminations: elims(5,
+				Cell{Row: 1, Col: 0}, Cell{Row: 1, Col: 1}, Cell{Row: 1, Col: 2},
+				Cell{Row: 2, Col: 0}, Cell{Row: 2, Col: 1}, Cell{Row: 2, Col: 2},
+			),
+		},
+	}, {
+		desc: `naked pair`,
+		setup: func(ls *logicSolver) {
+			set(ls, 0, 0, 1, 2)
+			set(ls, 0, 4, 1, 2)
+		},
+		technique: NakedPair,
+		expStep: Step{
+			Technique: NakedPair,
+			Cells:     []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 4}},
+			Eliminations: []Candidate{
+				{Row: 0, Col: 1, Digit: 1}, {Row: 0, Col: 1, Digit: 2},
+				{Row: 0, Col: 2, Digit: 1}, {Row: 0, Col: 2, Digit: 2},
+				{Row: 0, Col: 3, Digit: 1}, {Row: 0, Col: 3, Digit: 2},
+				{Row: 0, Col: 5, Digit: 1}, {Row: 0, Col: 5, Digit: 2},
+				{Row: 0, Col: 6, Digit: 1}, {Row: 0, Col: 6, Digit: 2},
+				{Row: 0, Col: 7, Digit: 1}, {Row: 0, Col: 7, Digit: 2},
+				{Row: 0, Col: 8, Digit: 1}, {Row: 0, Col: 8, Digit: 2},
+			},
+		},
+	}, {
+		desc: `naked triple`,
+		setup: func(ls *logicSolver) {
+			set(ls, 0, 0, 1, 2)
+			set(ls, 0, 4, 2, 3)
+			set(ls, 0, 8, 1, 3)
+			for c := 1; c < Dimension; c++ {
+				if c != 4 && c != 8 {
+					set(ls, 0, c, 3, 4, 5, 6, 7, 8, 9)
+				}
+			}
+		},
+		technique: NakedTriple,
+		expStep: Step{
+			Technique:    NakedTriple,
+			Cells:        []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 4}, {Row: 0, Col: 8}},
+			Eliminations: elims(3, rowExcept(0, 0, 4, 8)...),
+		},
+	}, {
+		desc: `hidden pair`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 0, 1, 2, 6)
+			remove(ls, 0, 2, 2, 6)
+			set(ls, 0, 2, 1, 2, 3)
+			set(ls, 0, 6, 1, 2, 9)
+		},
+		technique: HiddenPair,
+		expStep: Step{
+			Technique:    HiddenPair,
+			Cells:        []Cell{{Row: 0, Col: 2}, {Row: 0, Col: 6}},
+			Eliminations: append(elims(3, Cell{Row: 0, Col: 2}), elims(9, Cell{Row: 0, Col: 6})...),
+		},
+	}, {
+		desc: `hidden triple`,
+		setup: func(ls *logicSolver) {
+			for n := 1; n <= 3; n++ {
+				remove(ls, 0, n, 2, 5, 6)
+			}
+			set(ls, 0, 2, 1, 2, 8)
+			set(ls, 0, 5, 2, 3)
+			set(ls, 0, 6, 1, 3)
+		},
+		technique: HiddenTriple,
+		expStep: Step{
+			Technique:    HiddenTriple,
+			Cells:        []Cell{{Row: 0, Col: 2}, {Row: 0, Col: 5}, {Row: 0, Col: 6}},
+			Eliminations: elims(8, Cell{Row: 0, Col: 2}),
+		},
+	}, {
+		desc: `x-wing`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 1, 5, 1, 7)
+			remove(ls, 4, 5, 1, 7)
+		},
+		technique: XWing,
+		expStep: Step{
+			Technique: XWing,
+			Cells: []Cell{
+				{Row: 1, Col: 1}, {Row: 1, Col: 7},
+				{Row: 4, Col: 1}, {Row: 4, Col: 7},
+			},
+			Eliminations: elims(5,
+				Cell{Row: 0, Col: 1}, Cell{Row: 0, Col: 7},
+				Cell{Row: 2, Col: 1}, Cell{Row: 2, Col: 7},
+				Cell{Row: 3, Col: 1}, Cell{Row: 3, Col: 7},
+				Cell{Row: 5, Col: 1}, Cell{Row: 5, Col: 7},
+				Cell{Row: 6, Col: 1}, Cell{Row: 6, Col: 7},
+				Cell{Row: 7, Col: 1}, Cell{Row: 7, Col: 7},
+				Cell{Row: 8, Col: 1}, Cell{Row: 8, Col: 7},
+			),
+		},
+	}, {
+		desc: `swordfish`,
+		setup: func(ls *logicSolver) {
+			remove(ls, 0, 5, 0, 4)
+			remove(ls, 3, 5, 4, 8)
+			remove(ls, 6, 5, 0, 8)
+		},
+		technique: Swordfish,
+		expStep: Step{
+			Technique: Swordfish,
+			Cells: []Cell{
+				{Row: 0, Col: 0}, {Row: 0, Col: 4},
+				{Row: 3, Col: 4}, {Row: 3, Col: 8},
+				{Row: 6, Col: 0}, {Row: 6, Col: 8},
+			},
+			Eliminations: elims(5,
+				Cell{Row: 1, Col: 0}, Cell{Row: 1, Col: 4}, Cell{Row: 1, Col: 8},
+				Cell{Row: 2, Col: 0}, Cell{Row: 2, Col: 4}, Cell{Row: 2, Col: 8},
+				Cell{Row: 4, Col: 0}, Cell{Row: 4, Col: 4}, Cell{Row: 4, Col: 8},
+				Cell{Row: 5, Col: 0}, Cell{Row: 5, Col: 4}, Cell{Row: 5, Col: 8},
+				Cell{Row: 7, Col: 0}, Cell{Row: 7, Col: 4}, Cell{Row: 7, Col: 8},
+				Cell{Row: 8, Col: 0}, Cell{Row: 8, Col: 4}, Cell{Row: 8, Col: 8},
+			),
+		},
+	}, {
+		desc: `xy-wing`,
+		setup: func(ls *logicSolver) {
+			set(ls, 0, 0, 1, 2)
+			set(ls, 0, 4, 1, 3)
+			set(ls, 4, 0, 2, 3)
+		},
+		technique: XYWing,
+		expStep: Step{
+			Technique:    XYWing,
+			Cells:        []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 4}, {Row: 4, Col: 0}},
+			Eliminations: elims(3, Cell{Row: 4, Col: 4}),
+		},
+	}}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(NewEmptyBoard())
+			require.NoError(t, err)
+			ls := newLogicSolver(s)
+			tc.setup(ls)
+
+			step, ok := ls.find(tc.technique)
+			require.True(t, ok)
+			require.Equal(t, tc.expStep, step)
+		})
+	}
+}
@@ -6,12 +6,53 @@ import (
 
 type InvalidBoardError struct {
 	InvalidSquares []*InvalidSquareError
+	InvalidCages   []*InvalidCageError
 }
 
 func (ibe *InvalidBoardError) Error() string {
+	if len(ibe.InvalidCages) != 0 {
+		return fmt.Sprintf(`invalid board: %d invalid squares, %d invalid cages`, len(ibe.InvalidSquares), len(ibe.InvalidCages))
+	}
 	return fmt.Sprintf(`invalid board: %d invalid squares`, len(ibe.InvalidSquares))
 }
 
+// SizeError reports a board with the wrong number of rows or columns for its box size. It wraps
+// ErrWrongNumberOfRows or ErrWrongNumberOfCols.
+type SizeError struct {
+	Expected int
+	Err      error
+}
+
+func (se *SizeError) Error() string {
+	what := `rows`
+	if se.Err == ErrWrongNumberOfCols {
+		what = `cols`
+	}
+	return fmt.Sprintf(`expected %d %s`, se.Expected, what)
+}
+
+func (se *SizeError) Unwrap() error {
+	return se.Err
+}
+
+// canceledError reports that a search was canceled. It matches ErrCanceled as well as the
+// context's own error.
+type canceledError struct {
+	cause error
+}
+
+func (ce canceledError) Error() string {
+	return fmt.Sprintf(`%s: %s`, ErrCanceled, ce.cause)
+}
+
+func (ce canceledError) Is(target error) bool {
+	return target == ErrCanceled
+}
+
+func (ce canceledError) Unwrap() error {
+	return ce.cause
+}
+
 type InvalidSquareError struct {
 	Row int    `json:"row"`
 	Col int    `json:"col"`
@@ -37,9 +78,31 @@ const (
 
 	duplicateNumber
 	outOfRange
+	duplicateInCage
+	duplicateInDiagonal
+
+	emptyCage
+	cageTooLarge
+	cageOffBoard
+	cageRepeatsSquare
+	impossibleCageSum
+	overlappingCage
+	cageOverSum
+	cageSumMismatch
 )
 
 var reasonToMsg = map[invalidReason]string{
-	duplicateNumber: `duplicate number in row, column, or box`,
-	outOfRange:      `number out of range`,
+	duplicateNumber:     `duplicate number in row, column, or box`,
+	outOfRange:          `number out of range`,
+	duplicateInCage:     `duplicate number in cage`,
+	duplicateInDiagonal: `duplicate number in diagonal`,
+
+	emptyCage:         `cage has no squares`,
+	cageTooLarge:      `cage has more squares than there are numbers`,
+	cageOffBoard:      `cage has a square off the board`,
+	cageRepeatsSquare: `cage lists a square more than once`,
+	impossibleCageSum: `no distinct numbers fill the cage to its sum`,
+	overlappingCage:   `cage overlaps another cage`,
+	cageOverSum:       `numbers exceed the cage's sum`,
+	cageSumMismatch:   `numbers don't add up to the cage's sum`,
 }